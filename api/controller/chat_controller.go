@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/domain"
+	"github.com/lakshya1goel/Playzio/usecase"
+)
+
+type ChatController struct {
+	chatUsecase usecase.ChatUsecase
+}
+
+func NewChatController() *ChatController {
+	return &ChatController{
+		chatUsecase: usecase.NewChatUsecase(),
+	}
+}
+
+func (cc *ChatController) GetRoomMessages(c *gin.Context) {
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: "Invalid room ID",
+		})
+		return
+	}
+
+	var before uint64
+	if cursor := c.Query("before"); cursor != "" {
+		before, err = strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Message: "Invalid cursor",
+			})
+			return
+		}
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	history, httpErr := cc.chatUsecase.GetRoomMessages(c, uint(roomID), c.GetString("participant_id"), uint(before), limit)
+	if httpErr != nil {
+		c.JSON(httpErr.StatusCode, domain.ErrorResponse{
+			Message: httpErr.Message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Success: true,
+		Message: "Chat messages fetched successfully!",
+		Data:    history,
+	})
+}
@@ -19,7 +19,7 @@ func NewChatWSController(pool *websocket.ChatPool, handler websocket.ChatHandler
 }
 
 func (wsc *ChatWSController) HandleWebSocket(c *gin.Context) {
-	userId, _, conn, ok := util.UpgradeWithUserID(c)
+	userId, _, conn, ok := util.UpgradeWithParticipantID(c)
 	if !ok {
 		return
 	}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/domain"
+	"github.com/lakshya1goel/Playzio/usecase"
+)
+
+type GameController struct {
+	gameUsecase usecase.GameUsecase
+}
+
+func NewGameController() *GameController {
+	return &GameController{
+		gameUsecase: usecase.NewGameUsecase(),
+	}
+}
+
+func (gc *GameController) GetGame(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: "Invalid game ID",
+		})
+		return
+	}
+
+	game, httpErr := gc.gameUsecase.GetGame(c, uint(id))
+	if httpErr != nil {
+		c.JSON(httpErr.StatusCode, domain.ErrorResponse{
+			Message: httpErr.Message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Success: true,
+		Message: "Game fetched successfully!",
+		Data:    game,
+	})
+}
+
+func (gc *GameController) GetMyGameHistory(c *gin.Context) {
+	gc.respondWithGameHistory(c, c.GetString("participant_id"))
+}
+
+func (gc *GameController) GetGameHistory(c *gin.Context) {
+	gc.respondWithGameHistory(c, c.Param("participant_id"))
+}
+
+func (gc *GameController) respondWithGameHistory(c *gin.Context, participantID string) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	games, httpErr := gc.gameUsecase.GetGameHistory(c, participantID, limit)
+	if httpErr != nil {
+		c.JSON(httpErr.StatusCode, domain.ErrorResponse{
+			Message: httpErr.Message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Success: true,
+		Message: "Game history fetched successfully!",
+		Data:    games,
+	})
+}
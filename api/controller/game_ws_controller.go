@@ -1,8 +1,13 @@
 package controller
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
 	"github.com/lakshya1goel/Playzio/websocket"
 )
 
@@ -17,7 +22,15 @@ func NewGameWSController(pool *websocket.GamePool) *GameWSController {
 }
 
 func (wsc *GameWSController) HandleGameWebSocket(c *gin.Context) {
-	userId, userName, conn, ok := util.UpgradeWithUserID(c)
+	version, ok := protocol.Negotiate(c.Query("protocol_version"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: fmt.Sprintf("Unsupported protocol version, supported versions: %v", protocol.SupportedVersions),
+		})
+		return
+	}
+
+	userId, userName, conn, ok := util.UpgradeWithParticipantID(c)
 	if !ok {
 		return
 	}
@@ -28,7 +41,8 @@ func (wsc *GameWSController) HandleGameWebSocket(c *gin.Context) {
 			UserId:   userId,
 			UserName: userName,
 		},
-		Pool: wsc.pool,
+		Pool:            wsc.pool,
+		ProtocolVersion: version,
 	}
 
 	go wsc.pool.Read(client)
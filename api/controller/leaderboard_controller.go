@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/domain"
+	"github.com/lakshya1goel/Playzio/domain/dto"
+	"github.com/lakshya1goel/Playzio/usecase"
+)
+
+type LeaderboardController struct {
+	leaderboardUsecase usecase.LeaderboardUsecase
+}
+
+func NewLeaderboardController() *LeaderboardController {
+	return &LeaderboardController{
+		leaderboardUsecase: usecase.NewLeaderboardUsecase(),
+	}
+}
+
+func (lc *LeaderboardController) GetAllTimeLeaderboard(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	entries, err := lc.leaderboardUsecase.GetGlobalLeaderboard(c, usecase.LeaderboardAllTime, c.Query("sort"), limit)
+	lc.respond(c, entries, err)
+}
+
+func (lc *LeaderboardController) GetWeeklyLeaderboard(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	entries, err := lc.leaderboardUsecase.GetGlobalLeaderboard(c, usecase.LeaderboardWeekly, c.Query("sort"), limit)
+	lc.respond(c, entries, err)
+}
+
+func (lc *LeaderboardController) GetRoomLeaderboard(c *gin.Context) {
+	roomID, parseErr := strconv.ParseUint(c.Param("id"), 10, 64)
+	if parseErr != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: "Invalid room ID",
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	entries, err := lc.leaderboardUsecase.GetRoomLeaderboard(c, uint(roomID), c.Query("sort"), limit)
+	lc.respond(c, entries, err)
+}
+
+func (lc *LeaderboardController) respond(c *gin.Context, entries []dto.LeaderboardEntry, err *domain.HttpError) {
+	if err != nil {
+		c.JSON(err.StatusCode, domain.ErrorResponse{
+			Message: err.Message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Success: true,
+		Message: "Leaderboard fetched successfully!",
+		Data:    entries,
+	})
+}
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/bootstrap/redis"
+	"github.com/lakshya1goel/Playzio/domain"
+)
+
+type MonitoringController struct {
+	redis *redis.Redis
+}
+
+func NewMonitoringController(redisClient *redis.Redis) *MonitoringController {
+	return &MonitoringController{
+		redis: redisClient,
+	}
+}
+
+func (mc *MonitoringController) GetSubscriptions(c *gin.Context) {
+	if mc.redis == nil {
+		c.JSON(http.StatusServiceUnavailable, domain.ErrorResponse{
+			Message: "Redis is not connected",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Success: true,
+		Message: "Subscriptions fetched successfully!",
+		Data:    mc.redis.SubscriptionStats(),
+	})
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lakshya1goel/Playzio/domain"
@@ -30,11 +31,12 @@ func (rc *RoomController) CreateRoom(c *gin.Context) {
 	}
 
 	room := model.Room{
-		Name: request.Name,
-		Type: request.Type,
+		Name:     request.Name,
+		Type:     request.Type,
+		Language: request.Language,
 	}
 
-	response, err := rc.roomUsecase.CreateRoom(c, room)
+	response, err := rc.roomUsecase.CreateRoom(c, room, request.Settings)
 
 	if err != nil {
 		c.JSON(err.StatusCode, domain.ErrorResponse{
@@ -50,6 +52,38 @@ func (rc *RoomController) CreateRoom(c *gin.Context) {
 	})
 }
 
+func (rc *RoomController) UpdateRoomSettings(c *gin.Context) {
+	roomID, parseErr := strconv.ParseUint(c.Param("id"), 10, 64)
+	if parseErr != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: "Invalid room ID",
+		})
+		return
+	}
+
+	var request dto.GameSettingsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Message: "Invalid request data",
+		})
+		return
+	}
+
+	room, err := rc.roomUsecase.UpdateRoomSettings(c, uint(roomID), request)
+	if err != nil {
+		c.JSON(err.StatusCode, domain.ErrorResponse{
+			Message: err.Message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Success: true,
+		Message: "Game settings updated successfully!",
+		Data:    room,
+	})
+}
+
 func (rc *RoomController) JoinRoom(c *gin.Context) {
 	joinCode := c.Query("join_code")
 	if joinCode == "" {
@@ -34,14 +34,22 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		if claims["type"] == "guest" {
+			guestID, ok := claims["guest_id"].(string)
+			if !ok || guestID == "" {
+				c.JSON(http.StatusUnauthorized, gin.H{"message": "Invalid token (missing guest_id)"})
+				c.Abort()
+				return
+			}
 			c.Set("user_type", "guest")
 			c.Set("user_name", claims["name"])
-			c.Set("guest_id", claims["guest_id"].(string))
+			c.Set("guest_id", guestID)
+			c.Set("participant_id", util.GuestParticipantID(guestID))
 		} else {
 			if userID, ok := claims["user_id"].(float64); ok {
 				c.Set("user_type", "google")
 				c.Set("user_id", uint(userID))
 				c.Set("user_name", claims["name"])
+				c.Set("participant_id", util.UserParticipantID(uint(userID)))
 			} else {
 				c.JSON(http.StatusUnauthorized, gin.H{"message": "Invalid token (missing user_id)"})
 				c.Abort()
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	controller "github.com/lakshya1goel/Playzio/api/controller"
+	"github.com/lakshya1goel/Playzio/api/middleware"
+)
+
+func GameRoutes(router *gin.RouterGroup, gameController *controller.GameController) {
+	gameRouter := router.Group("/game")
+	gameRouter.Use(middleware.AuthMiddleware())
+	{
+		gameRouter.GET("/history", gameController.GetMyGameHistory)
+		gameRouter.GET("/history/:participant_id", gameController.GetGameHistory)
+		gameRouter.GET("/:id", gameController.GetGame)
+	}
+}
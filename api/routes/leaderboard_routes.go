@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	controller "github.com/lakshya1goel/Playzio/api/controller"
+	"github.com/lakshya1goel/Playzio/api/middleware"
+)
+
+func LeaderboardRoutes(router *gin.RouterGroup, leaderboardController *controller.LeaderboardController) {
+	leaderboardRouter := router.Group("/leaderboard")
+	leaderboardRouter.Use(middleware.AuthMiddleware())
+	{
+		leaderboardRouter.GET("/", leaderboardController.GetAllTimeLeaderboard)
+		leaderboardRouter.GET("/weekly", leaderboardController.GetWeeklyLeaderboard)
+		leaderboardRouter.GET("/room/:id", leaderboardController.GetRoomLeaderboard)
+	}
+}
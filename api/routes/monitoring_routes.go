@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	controller "github.com/lakshya1goel/Playzio/api/controller"
+)
+
+func MonitoringRoutes(router *gin.RouterGroup, monitoringController *controller.MonitoringController) {
+	monitoringRouter := router.Group("/monitoring")
+	{
+		monitoringRouter.GET("/subscriptions", monitoringController.GetSubscriptions)
+	}
+}
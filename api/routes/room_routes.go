@@ -6,7 +6,7 @@ import (
 	"github.com/lakshya1goel/Playzio/api/middleware"
 )
 
-func RoomRoutes(router *gin.RouterGroup, roomController *controller.RoomController) {
+func RoomRoutes(router *gin.RouterGroup, roomController *controller.RoomController, chatController *controller.ChatController) {
 	roomRouter := router.Group("/room")
 	roomRouter.Use(middleware.AuthMiddleware())
 	{
@@ -14,5 +14,7 @@ func RoomRoutes(router *gin.RouterGroup, roomController *controller.RoomControll
 		roomRouter.POST("/join", roomController.JoinRoom)
 		roomRouter.GET("/public", roomController.GetAllPublicRooms)
 		roomRouter.POST("/leave", roomController.LeaveRoom)
+		roomRouter.PUT("/:id/settings", roomController.UpdateRoomSettings)
+		roomRouter.GET("/:id/messages", chatController.GetRoomMessages)
 	}
 }
@@ -2,7 +2,9 @@ package bootstrap
 
 import (
 	"fmt"
+	"log"
 
+	"github.com/lakshya1goel/Playzio/bootstrap/dictionary"
 	"github.com/lakshya1goel/Playzio/bootstrap/redis"
 	"github.com/lakshya1goel/Playzio/websocket"
 )
@@ -14,26 +16,38 @@ type Application struct {
 	RedisClient *redis.Redis
 }
 
-func App() Application {
+func App(rooms websocket.RoomProvider, games websocket.GameRecorder, chats websocket.ChatHistory, members websocket.MemberStore) Application {
 	app := &Application{}
 	app.Env = NewEnv()
 
+	if err := dictionary.LoadDictionaries(app.Env.DictionaryDir, app.Env.DefaultLanguage); err != nil {
+		log.Fatal("Dictionaries can't be loaded: ", err)
+	}
+	fmt.Println("Dictionaries loaded:", dictionary.Dictionaries.Languages())
+
 	err := redis.ConnectRedis(
 		app.Env.RedisHost,
 		app.Env.RedisPort,
 		app.Env.RedisPass,
 		app.Env.RedisDB,
 	)
+	app.RedisClient = redis.RedisClient
+
+	var bus websocket.RoomBus
 	if err != nil {
-		fmt.Printf("Redis connection failed: %v. Chat will work without Redis.\n", err)
-		app.RedisClient = nil
+		fmt.Printf("Redis connection failed: %v. Chat will use in-process delivery until Redis recovers and game rooms will only be shared within this process.\n", err)
 	} else {
 		fmt.Println("Redis connected successfully")
-		app.RedisClient = redis.RedisClient
+		bus = app.RedisClient
+	}
+
+	var chatBus websocket.RemoteMessageBus
+	if app.RedisClient != nil {
+		chatBus = app.RedisClient
 	}
 
-	app.ChatPool = websocket.NewChatPool(app.RedisClient)
-	app.GamePool = websocket.NewGamePool()
+	app.GamePool = websocket.NewGamePool(dictionary.Dictionaries, rooms, games, bus)
+	app.ChatPool = websocket.NewChatPool(websocket.NewFallbackMessageBus(chatBus), chats, members, app.GamePool)
 	go app.ChatPool.Start()
 	go app.GamePool.Start()
 	return *app
@@ -12,7 +12,15 @@ func InitDB() error {
 		return fmt.Errorf("database connection not established. Call ConnectDb first")
 	}
 
-	err := Db.AutoMigrate(&model.User{}, &model.Room{}, &model.RoomMember{})
+	err := Db.AutoMigrate(
+		&model.User{},
+		&model.Room{},
+		&model.RoomMember{},
+		&model.Game{},
+		&model.GamePlayer{},
+		&model.GameTurn{},
+		&model.ChatMessage{},
+	)
 	if err != nil {
 		return fmt.Errorf("error creating expenses table: %v", err)
 	}
@@ -0,0 +1,158 @@
+package dictionary
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/lakshya1goel/Playzio/bootstrap/util"
+)
+
+const DefaultLanguage = "en"
+
+const MinAlphabetLetterWords = 5
+
+type Dictionary interface {
+	Language() string
+	IsWordValid(word string) bool
+	GeneratePrompt(round int) string
+	Words() []string
+	Alphabet() []string
+	PromptSolutions(prompt string) int
+	Rarity(word string) float64
+}
+
+type wordListDictionary struct {
+	language string
+	words    []string
+	wordSet  map[string]struct{}
+	prompts  *util.PromptGenerator
+	alphabet []string
+	rarity   map[string]float64
+}
+
+func NewWordListDictionary(language string, words []string, seed int64) (Dictionary, error) {
+	wordSet := make(map[string]struct{}, len(words))
+	wordList := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" {
+			continue
+		}
+		if _, exists := wordSet[word]; exists {
+			continue
+		}
+		wordSet[word] = struct{}{}
+		wordList = append(wordList, word)
+	}
+
+	prompts := util.NewPromptGenerator(wordList, seed)
+	if prompts.PromptCount() == 0 {
+		return nil, fmt.Errorf("dictionary %q does not have enough words to generate prompts", language)
+	}
+
+	counts := countLetters(wordList)
+	return &wordListDictionary{
+		language: language,
+		words:    wordList,
+		wordSet:  wordSet,
+		prompts:  prompts,
+		alphabet: buildAlphabet(counts),
+		rarity:   buildLetterRarity(counts),
+	}, nil
+}
+
+func countLetters(words []string) map[rune]int {
+	counts := make(map[rune]int)
+	for _, word := range words {
+		seen := make(map[rune]struct{})
+		for _, r := range word {
+			if unicode.IsLetter(r) || unicode.IsMark(r) {
+				seen[r] = struct{}{}
+			}
+		}
+		for r := range seen {
+			counts[r]++
+		}
+	}
+	return counts
+}
+
+func buildAlphabet(counts map[rune]int) []string {
+	alphabet := make([]string, 0, len(counts))
+	for r, count := range counts {
+		if count >= MinAlphabetLetterWords {
+			alphabet = append(alphabet, string(r))
+		}
+	}
+	sort.Strings(alphabet)
+	return alphabet
+}
+
+func buildLetterRarity(counts map[rune]int) map[string]float64 {
+	maxCount := 0
+	for _, count := range counts {
+		maxCount = max(maxCount, count)
+	}
+
+	rarity := make(map[string]float64, len(counts))
+	for r, count := range counts {
+		rarity[string(r)] = 1 - float64(count)/float64(maxCount)
+	}
+	return rarity
+}
+
+func (d *wordListDictionary) Language() string {
+	return d.language
+}
+
+func (d *wordListDictionary) IsWordValid(word string) bool {
+	_, exists := d.wordSet[strings.ToLower(word)]
+	return exists
+}
+
+func (d *wordListDictionary) GeneratePrompt(round int) string {
+	return d.prompts.Generate(round)
+}
+
+func (d *wordListDictionary) Words() []string {
+	return d.words
+}
+
+func (d *wordListDictionary) Alphabet() []string {
+	return d.alphabet
+}
+
+func (d *wordListDictionary) PromptSolutions(prompt string) int {
+	return d.prompts.Solutions(prompt)
+}
+
+func (d *wordListDictionary) Rarity(word string) float64 {
+	total, letters := 0.0, 0
+	for _, r := range strings.ToLower(word) {
+		if rarity, exists := d.rarity[string(r)]; exists {
+			total += rarity
+			letters++
+		}
+	}
+	if letters == 0 {
+		return 0
+	}
+	return total / float64(letters)
+}
+
+func readWords(language string, r io.Reader) (Dictionary, error) {
+	words := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		words = append(words, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading %q word list: %v", language, err)
+	}
+	return NewWordListDictionary(language, words, time.Now().UnixNano())
+}
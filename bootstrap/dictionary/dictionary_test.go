@@ -0,0 +1,143 @@
+package dictionary
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeWordList(t *testing.T, dir string, name string, words []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, words, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func embeddedWordList(t *testing.T) []byte {
+	t.Helper()
+
+	words, err := embeddedWords.ReadFile("words/en.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return words
+}
+
+func TestEmbeddedDictionary(t *testing.T) {
+	if languages := EmbeddedLanguages(); !slices.Contains(languages, DefaultLanguage) {
+		t.Fatalf("expected %q among embedded languages, got %v", DefaultLanguage, languages)
+	}
+
+	dict, err := NewEmbeddedDictionary(DefaultLanguage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dict.IsWordValid("About") || dict.IsWordValid("zzzz") {
+		t.Fatal("expected embedded words to be matched case-insensitively")
+	}
+	if len(dict.Alphabet()) == 0 || dict.GeneratePrompt(1) == "" {
+		t.Fatal("expected embedded dictionary to provide an alphabet and prompts")
+	}
+
+	if _, err := NewEmbeddedDictionary("xx"); err == nil {
+		t.Fatal("expected an error for a language without an embedded word list")
+	}
+}
+
+func TestEmbeddedLanguages(t *testing.T) {
+	tests := []struct {
+		language string
+		word     string
+		letter   string
+	}{
+		{language: "en", word: "about", letter: "z"},
+		{language: "es", word: "corazón", letter: "ñ"},
+		{language: "hi", word: "पानी", letter: "ा"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			dict, err := NewEmbeddedDictionary(tt.language)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !dict.IsWordValid(tt.word) {
+				t.Fatalf("expected %q to be a %s word", tt.word, tt.language)
+			}
+			if dict.GeneratePrompt(1) == "" {
+				t.Fatal("expected a prompt")
+			}
+			if !slices.Contains(dict.Alphabet(), tt.letter) {
+				t.Fatalf("expected %q in the alphabet %v", tt.letter, dict.Alphabet())
+			}
+		})
+	}
+}
+
+func TestFileDictionary(t *testing.T) {
+	dir := t.TempDir()
+	path := writeWordList(t, dir, "fr.txt", embeddedWordList(t))
+
+	dict, err := NewFileDictionary("fr", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dict.Language() != "fr" || !dict.IsWordValid("able") {
+		t.Fatalf("unexpected file dictionary %q", dict.Language())
+	}
+
+	if _, err := NewFileDictionary("fr", filepath.Join(dir, "missing.txt")); err == nil {
+		t.Fatal("expected an error for a missing word list")
+	}
+	tiny := writeWordList(t, dir, "tiny.txt", []byte("a\nb\n"))
+	if _, err := NewFileDictionary("tiny", tiny); err == nil {
+		t.Fatal("expected an error for a word list too small to generate prompts")
+	}
+}
+
+func TestRegistryFallsBackToDefaultLanguage(t *testing.T) {
+	dir := t.TempDir()
+	writeWordList(t, dir, "fr.txt", embeddedWordList(t))
+	writeWordList(t, dir, "notes.md", []byte("ignored"))
+
+	registry := NewRegistry(DefaultLanguage)
+	english, err := NewEmbeddedDictionary(DefaultLanguage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry.Register(english)
+	if err := registry.LoadDirectory(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if languages := registry.Languages(); !slices.Equal(languages, []string{"en", "fr"}) {
+		t.Fatalf("expected en and fr, got %v", languages)
+	}
+	if dict := registry.Get("fr"); dict.Language() != "fr" {
+		t.Fatalf("expected the fr dictionary, got %q", dict.Language())
+	}
+	if dict := registry.Get("de"); dict.Language() != DefaultLanguage {
+		t.Fatalf("expected unknown languages to fall back to %q, got %q", DefaultLanguage, dict.Language())
+	}
+}
+
+func TestLegacyWordListUsesConfiguredDefaultLanguage(t *testing.T) {
+	dir := t.TempDir()
+	legacy := writeWordList(t, dir, "wordlist.txt", embeddedWordList(t))
+
+	registry, err := loadRegistry("", "fr", legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if registry.DefaultLanguage() != "fr" || !registry.Has("fr") {
+		t.Fatalf("expected the legacy word list under fr, got %v", registry.Languages())
+	}
+
+	if _, err := loadRegistry("", "de", filepath.Join(dir, "missing.txt")); err == nil {
+		t.Fatal("expected an error when the default language has no dictionary")
+	}
+}
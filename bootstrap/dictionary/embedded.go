@@ -0,0 +1,34 @@
+package dictionary
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+)
+
+//go:embed words/*.txt
+var embeddedWords embed.FS
+
+func NewEmbeddedDictionary(language string) (Dictionary, error) {
+	file, err := embeddedWords.Open(path.Join("words", language+".txt"))
+	if err != nil {
+		return nil, fmt.Errorf("no embedded word list for language %q", language)
+	}
+	defer file.Close()
+
+	return readWords(language, file)
+}
+
+func EmbeddedLanguages() []string {
+	entries, err := embeddedWords.ReadDir("words")
+	if err != nil {
+		return nil
+	}
+
+	languages := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		languages = append(languages, strings.TrimSuffix(entry.Name(), ".txt"))
+	}
+	return languages
+}
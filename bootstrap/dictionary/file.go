@@ -0,0 +1,18 @@
+package dictionary
+
+import (
+	"fmt"
+	"os"
+)
+
+const LegacyWordListPath = "wordlist.txt"
+
+func NewFileDictionary(language, path string) (Dictionary, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	return readWords(language, file)
+}
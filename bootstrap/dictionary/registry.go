@@ -0,0 +1,126 @@
+package dictionary
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var Dictionaries *Registry
+
+type Registry struct {
+	dictionaries    map[string]Dictionary
+	defaultLanguage string
+	mu              sync.RWMutex
+}
+
+func NewRegistry(defaultLanguage string) *Registry {
+	return &Registry{
+		dictionaries:    make(map[string]Dictionary),
+		defaultLanguage: defaultLanguage,
+	}
+}
+
+func (r *Registry) Register(dict Dictionary) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dictionaries[dict.Language()] = dict
+}
+
+func (r *Registry) Has(language string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, exists := r.dictionaries[language]
+	return exists
+}
+
+func (r *Registry) Get(language string) Dictionary {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if dict, exists := r.dictionaries[language]; exists {
+		return dict
+	}
+	return r.dictionaries[r.defaultLanguage]
+}
+
+func (r *Registry) DefaultLanguage() string {
+	return r.defaultLanguage
+}
+
+func (r *Registry) Languages() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	languages := make([]string, 0, len(r.dictionaries))
+	for language := range r.dictionaries {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+func (r *Registry) LoadDirectory(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read dictionary directory %s: %v", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".txt" {
+			continue
+		}
+		language := strings.TrimSuffix(entry.Name(), ".txt")
+		dict, err := NewFileDictionary(language, filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		r.Register(dict)
+	}
+	return nil
+}
+
+func LoadDictionaries(dir, defaultLanguage string) error {
+	registry, err := loadRegistry(dir, defaultLanguage, LegacyWordListPath)
+	if err != nil {
+		return err
+	}
+
+	Dictionaries = registry
+	return nil
+}
+
+func loadRegistry(dir, defaultLanguage, legacyPath string) (*Registry, error) {
+	if defaultLanguage == "" {
+		defaultLanguage = DefaultLanguage
+	}
+	registry := NewRegistry(defaultLanguage)
+
+	for _, language := range EmbeddedLanguages() {
+		dict, err := NewEmbeddedDictionary(language)
+		if err != nil {
+			return nil, err
+		}
+		registry.Register(dict)
+	}
+
+	if _, err := os.Stat(legacyPath); err == nil {
+		dict, err := NewFileDictionary(defaultLanguage, legacyPath)
+		if err != nil {
+			return nil, err
+		}
+		registry.Register(dict)
+	}
+
+	if dir != "" {
+		if err := registry.LoadDirectory(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	if !registry.Has(defaultLanguage) {
+		return nil, fmt.Errorf("no dictionary found for default language %q", defaultLanguage)
+	}
+	return registry, nil
+}
@@ -16,6 +16,9 @@ type Env struct {
 	RedisPort string `mapstructure:"REDIS_PORT"`
 	RedisPass string `mapstructure:"REDIS_PASSWORD"`
 	RedisDB   int    `mapstructure:"REDIS_DB"`
+
+	DictionaryDir   string `mapstructure:"DICTIONARY_DIR"`
+	DefaultLanguage string `mapstructure:"DEFAULT_LANGUAGE"`
 }
 
 func NewEnv() *Env {
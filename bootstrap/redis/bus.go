@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var acquireLeaseScript = redis.NewScript(`
+local owner = redis.call("GET", KEYS[1])
+if owner and owner ~= ARGV[1] then
+	return owner
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+return ARGV[1]
+`)
+
+var releaseLeaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (r *Redis) Publish(channel string, data []byte) error {
+	if err := r.client.Publish(context.Background(), channel, data).Err(); err != nil {
+		fmt.Println("Error publishing message: ", err)
+		return err
+	}
+	return nil
+}
+
+func (r *Redis) Subscribe(channel string, handler func([]byte)) error {
+	if err := r.subscriber.subscribe(channel, handler); err != nil {
+		fmt.Println("Error subscribing to channel: ", err)
+		return err
+	}
+	return nil
+}
+
+func (r *Redis) Unsubscribe(channel string) error {
+	if err := r.subscriber.unsubscribe(channel); err != nil {
+		fmt.Println("Error unsubscribing from channel: ", err)
+		return err
+	}
+	return nil
+}
+
+func (r *Redis) AcquireLease(key string, owner string, ttl time.Duration) (string, error) {
+	current, err := acquireLeaseScript.Run(context.Background(), r.client, []string{key}, owner, ttl.Milliseconds()).Text()
+	if err != nil {
+		fmt.Println("Error acquiring lease: ", err)
+		return "", err
+	}
+	return current, nil
+}
+
+func (r *Redis) LeaseOwner(key string) (string, error) {
+	owner, err := r.client.Get(context.Background(), key).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		fmt.Println("Error reading lease owner: ", err)
+		return "", err
+	}
+	return owner, nil
+}
+
+func (r *Redis) ReleaseLease(key string, owner string) error {
+	if err := releaseLeaseScript.Run(context.Background(), r.client, []string{key}, owner).Err(); err != nil {
+		fmt.Println("Error releasing lease: ", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package redis
+
+import (
+	"sync"
+	"time"
+)
+
+type Memory struct {
+	mu            sync.Mutex
+	subscriptions map[string][]*channelQueue
+	leases        map[string]memoryLease
+}
+
+type memoryLease struct {
+	owner   string
+	expires time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		subscriptions: make(map[string][]*channelQueue),
+		leases:        make(map[string]memoryLease),
+	}
+}
+
+func (m *Memory) Publish(channel string, data []byte) error {
+	m.mu.Lock()
+	subscriptions := m.subscriptions[channel]
+	m.mu.Unlock()
+
+	for _, subscription := range subscriptions {
+		subscription.push(append([]byte(nil), data...))
+	}
+	return nil
+}
+
+func (m *Memory) Subscribe(channel string, handler func([]byte)) error {
+	subscription := newChannelQueue(handler)
+
+	m.mu.Lock()
+	m.subscriptions[channel] = append(m.subscriptions[channel], subscription)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Memory) Unsubscribe(channel string) error {
+	m.mu.Lock()
+	subscriptions := m.subscriptions[channel]
+	delete(m.subscriptions, channel)
+	m.mu.Unlock()
+
+	for _, subscription := range subscriptions {
+		subscription.close()
+	}
+	return nil
+}
+
+func (m *Memory) AcquireLease(key string, owner string, ttl time.Duration) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lease, exists := m.leases[key]
+	if exists && lease.owner != owner && time.Now().Before(lease.expires) {
+		return lease.owner, nil
+	}
+	m.leases[key] = memoryLease{owner: owner, expires: time.Now().Add(ttl)}
+	return owner, nil
+}
+
+func (m *Memory) LeaseOwner(key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lease, exists := m.leases[key]
+	if !exists || !time.Now().Before(lease.expires) {
+		return "", nil
+	}
+	return lease.owner, nil
+}
+
+func (m *Memory) ReleaseLease(key string, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if lease, exists := m.leases[key]; exists && lease.owner == owner {
+		delete(m.leases, key)
+	}
+	return nil
+}
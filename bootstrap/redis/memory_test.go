@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryLeaseHasOneOwnerUntilItExpires(t *testing.T) {
+	memory := NewMemory()
+
+	if owner, _ := memory.AcquireLease("room:1", "a", 50*time.Millisecond); owner != "a" {
+		t.Fatalf("expected a to acquire the lease, got %q", owner)
+	}
+	if owner, _ := memory.AcquireLease("room:1", "b", time.Second); owner != "a" {
+		t.Fatalf("expected a to keep the lease, got %q", owner)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if owner, _ := memory.AcquireLease("room:1", "b", time.Second); owner != "b" {
+		t.Fatalf("expected b to take over the expired lease, got %q", owner)
+	}
+
+	memory.ReleaseLease("room:1", "a")
+	if owner, _ := memory.AcquireLease("room:1", "c", time.Second); owner != "b" {
+		t.Fatalf("expected only the owner to release the lease, got %q", owner)
+	}
+}
+
+func TestMemoryLeaseOwnerDoesNotClaimTheLease(t *testing.T) {
+	memory := NewMemory()
+
+	if owner, _ := memory.LeaseOwner("room:1"); owner != "" {
+		t.Fatalf("expected no owner, got %q", owner)
+	}
+	if owner, _ := memory.AcquireLease("room:1", "a", 50*time.Millisecond); owner != "a" {
+		t.Fatalf("expected a to acquire the lease after a lookup, got %q", owner)
+	}
+	if owner, _ := memory.LeaseOwner("room:1"); owner != "a" {
+		t.Fatalf("expected a to own the lease, got %q", owner)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if owner, _ := memory.LeaseOwner("room:1"); owner != "" {
+		t.Fatalf("expected the expired lease to have no owner, got %q", owner)
+	}
+}
+
+func TestMemoryDeliversMessagesInOrder(t *testing.T) {
+	memory := NewMemory()
+	received := make(chan string, 3)
+	memory.Subscribe("node:a", func(data []byte) {
+		received <- string(data)
+	})
+
+	for _, message := range []string{"one", "two", "three"} {
+		memory.Publish("node:a", []byte(message))
+	}
+	memory.Publish("node:b", []byte("elsewhere"))
+
+	for _, expected := range []string{"one", "two", "three"} {
+		select {
+		case message := <-received:
+			if message != expected {
+				t.Fatalf("expected %q, got %q", expected, message)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %q", expected)
+		}
+	}
+
+	memory.Unsubscribe("node:a")
+	memory.Publish("node:a", []byte("late"))
+	select {
+	case message := <-received:
+		t.Fatalf("unexpected message after unsubscribe: %q", message)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -11,8 +11,8 @@ import (
 )
 
 type Redis struct {
-	client *redis.Client
-	pubsub *redis.PubSub
+	client     *redis.Client
+	subscriber *subscriber
 }
 
 var RedisClient *Redis
@@ -25,31 +25,35 @@ func ConnectRedis(host, port, password string, db int) error {
 		PoolSize: 10,
 	})
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	RedisClient = &Redis{
+		client:     client,
+		subscriber: newSubscriber(client),
+	}
 
-	if _, err := client.Ping(ctx).Result(); err != nil {
+	if err := RedisClient.Ping(); err != nil {
 		fmt.Println("Redis connection failed with error: ", err)
 		return err
 	}
 
-	RedisClient = &Redis{
-		client: client,
-	}
-
 	fmt.Println("Redis connected successfully")
 	return nil
 }
 
+func (r *Redis) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return r.client.Ping(ctx).Err()
+}
+
 func (r *Redis) PublishToRoom(roomID uint, message model.ChatMessage) error {
-	channel := fmt.Sprintf("room:%d", roomID)
 	messageJSON, err := json.Marshal(message)
 	if err != nil {
 		fmt.Println("Error marshalling message: ", err)
 		return err
 	}
 
-	if err := r.client.Publish(context.Background(), channel, messageJSON).Err(); err != nil {
+	if err := r.client.Publish(context.Background(), roomChannel(roomID), messageJSON).Err(); err != nil {
 		fmt.Println("Error publishing message: ", err)
 		return err
 	}
@@ -58,47 +62,32 @@ func (r *Redis) PublishToRoom(roomID uint, message model.ChatMessage) error {
 }
 
 func (r *Redis) SubscribeToRoom(roomID uint, messageHandler func(model.ChatMessage)) error {
-	channel := fmt.Sprintf("room:%d", roomID)
-	pubsub := r.client.Subscribe(context.Background(), channel)
-	r.pubsub = pubsub
-
-	if _, err := pubsub.Receive(context.Background()); err != nil {
-		fmt.Println("Error subscribing to channel: ", err)
-		return err
-	}
-
-	go func() {
-		ch := pubsub.Channel()
-		for msg := range ch {
-			var message model.ChatMessage
-			if err := json.Unmarshal([]byte(msg.Payload), &message); err != nil {
-				fmt.Println("Error unmarshalling message: ", err)
-				continue
-			}
-			messageHandler(message)
+	return r.Subscribe(roomChannel(roomID), func(data []byte) {
+		var message model.ChatMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			fmt.Println("Error unmarshalling message: ", err)
+			return
 		}
-	}()
-
-	return nil
+		messageHandler(message)
+	})
 }
 
 func (r *Redis) UnsubscribeFromRoom(roomID uint) error {
-	channel := fmt.Sprintf("room:%d", roomID)
-	if r.pubsub != nil {
-		if err := r.pubsub.Unsubscribe(context.Background(), channel); err != nil {
-			fmt.Println("Error unsubscribing from channel: ", err)
-			return err
-		}
-	}
-	return nil
+	return r.Unsubscribe(roomChannel(roomID))
+}
+
+func (r *Redis) SubscriptionStats() SubscriptionStats {
+	return r.subscriber.stats()
 }
 
 func (r *Redis) Close() error {
-	if r.pubsub != nil {
-		if err := r.pubsub.Close(); err != nil {
-			fmt.Println("Error closing pubsub: ", err)
-			return err
-		}
+	if err := r.subscriber.close(); err != nil {
+		fmt.Println("Error closing pubsub: ", err)
+		return err
 	}
 	return nil
 }
+
+func roomChannel(roomID uint) string {
+	return fmt.Sprintf("room:%d", roomID)
+}
@@ -0,0 +1,224 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	MinReconnectBackoff = 100 * time.Millisecond
+	MaxReconnectBackoff = 10 * time.Second
+)
+
+type SubscriptionStats struct {
+	Channels   int            `json:"channels"`
+	ByPrefix   map[string]int `json:"by_prefix"`
+	Connected  bool           `json:"connected"`
+	Reconnects int            `json:"reconnects"`
+}
+
+type pubSub interface {
+	Subscribe(ctx context.Context, channels ...string) error
+	Unsubscribe(ctx context.Context, channels ...string) error
+	Receive(ctx context.Context) (any, error)
+	Close() error
+}
+
+type subscriber struct {
+	pubsub     pubSub
+	mu         sync.RWMutex
+	queues     map[string]*channelQueue
+	connected  bool
+	reconnects int
+	closed     bool
+}
+
+type channelQueue struct {
+	mu     sync.Mutex
+	queue  [][]byte
+	notify chan struct{}
+	closed bool
+}
+
+func newSubscriber(client *redis.Client) *subscriber {
+	return startSubscriber(client.Subscribe(context.Background()))
+}
+
+func startSubscriber(pubsub pubSub) *subscriber {
+	s := &subscriber{
+		pubsub:    pubsub,
+		queues:    make(map[string]*channelQueue),
+		connected: true,
+	}
+	go s.run()
+	return s
+}
+
+func (s *subscriber) subscribe(channel string, handler func([]byte)) error {
+	s.mu.Lock()
+	previous, subscribed := s.queues[channel]
+	s.queues[channel] = newChannelQueue(handler)
+	s.mu.Unlock()
+
+	if subscribed {
+		previous.close()
+		return nil
+	}
+	return s.pubsub.Subscribe(context.Background(), channel)
+}
+
+func (s *subscriber) unsubscribe(channel string) error {
+	s.mu.Lock()
+	queue, subscribed := s.queues[channel]
+	delete(s.queues, channel)
+	s.mu.Unlock()
+
+	if !subscribed {
+		return nil
+	}
+	queue.close()
+	return s.pubsub.Unsubscribe(context.Background(), channel)
+}
+
+func (s *subscriber) run() {
+	backoff := MinReconnectBackoff
+	for {
+		msg, err := s.pubsub.Receive(context.Background())
+		if err != nil {
+			if s.isClosed() {
+				return
+			}
+
+			fmt.Println("Redis subscription error, retrying in", backoff, ":", err)
+			s.setConnected(false)
+			time.Sleep(backoff)
+			backoff = min(backoff*2, MaxReconnectBackoff)
+			continue
+		}
+
+		s.setConnected(true)
+		backoff = MinReconnectBackoff
+
+		if message, ok := msg.(*redis.Message); ok {
+			s.dispatch(message.Channel, []byte(message.Payload))
+		}
+	}
+}
+
+func (s *subscriber) dispatch(channel string, data []byte) {
+	s.mu.RLock()
+	queue := s.queues[channel]
+	s.mu.RUnlock()
+
+	if queue != nil {
+		queue.push(data)
+	}
+}
+
+func (s *subscriber) setConnected(connected bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if connected && !s.connected {
+		s.reconnects++
+	}
+	s.connected = connected
+}
+
+func (s *subscriber) close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	queues := s.queues
+	s.queues = make(map[string]*channelQueue)
+	s.mu.Unlock()
+
+	for _, queue := range queues {
+		queue.close()
+	}
+	if err := s.pubsub.Close(); err != nil && !errors.Is(err, redis.ErrClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *subscriber) isClosed() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.closed
+}
+
+func (s *subscriber) stats() SubscriptionStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byPrefix := make(map[string]int)
+	for channel := range s.queues {
+		prefix, _, _ := strings.Cut(channel, ":")
+		byPrefix[prefix]++
+	}
+
+	return SubscriptionStats{
+		Channels:   len(s.queues),
+		ByPrefix:   byPrefix,
+		Connected:  s.connected,
+		Reconnects: s.reconnects,
+	}
+}
+
+func newChannelQueue(handler func([]byte)) *channelQueue {
+	q := &channelQueue{notify: make(chan struct{}, 1)}
+	go q.run(handler)
+	return q
+}
+
+func (q *channelQueue) push(data []byte) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.queue = append(q.queue, data)
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (q *channelQueue) run(handler func([]byte)) {
+	for range q.notify {
+		for {
+			q.mu.Lock()
+			if q.closed || len(q.queue) == 0 {
+				q.mu.Unlock()
+				break
+			}
+			data := q.queue[0]
+			q.queue = q.queue[1:]
+			q.mu.Unlock()
+
+			handler(data)
+		}
+	}
+}
+
+func (q *channelQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.closed = true
+	close(q.notify)
+}
@@ -0,0 +1,161 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type fakePubSub struct {
+	mu       sync.Mutex
+	channels map[string]bool
+	messages chan *redis.Message
+	closed   chan struct{}
+}
+
+func newFakePubSub() *fakePubSub {
+	return &fakePubSub{
+		channels: make(map[string]bool),
+		messages: make(chan *redis.Message, 16),
+		closed:   make(chan struct{}),
+	}
+}
+
+func (f *fakePubSub) Subscribe(ctx context.Context, channels ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, channel := range channels {
+		f.channels[channel] = true
+	}
+	return nil
+}
+
+func (f *fakePubSub) Unsubscribe(ctx context.Context, channels ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, channel := range channels {
+		delete(f.channels, channel)
+	}
+	return nil
+}
+
+func (f *fakePubSub) Receive(ctx context.Context) (any, error) {
+	select {
+	case message := <-f.messages:
+		return message, nil
+	case <-f.closed:
+		return nil, redis.ErrClosed
+	}
+}
+
+func (f *fakePubSub) Close() error {
+	close(f.closed)
+	return nil
+}
+
+func (f *fakePubSub) publish(channel string, payload string) {
+	f.messages <- &redis.Message{Channel: channel, Payload: payload}
+}
+
+func (f *fakePubSub) subscribed() map[string]bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	subscribed := make(map[string]bool, len(f.channels))
+	for channel := range f.channels {
+		subscribed[channel] = true
+	}
+	return subscribed
+}
+
+func forward(received chan<- string, channel string) func([]byte) {
+	return func(data []byte) {
+		received <- channel + "=" + string(data)
+	}
+}
+
+func expectReceived(t *testing.T, received <-chan string, expected string) {
+	t.Helper()
+	select {
+	case message := <-received:
+		if message != expected {
+			t.Fatalf("expected %q, got %q", expected, message)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for %q", expected)
+	}
+}
+
+func TestSubscriberDispatchesByChannel(t *testing.T) {
+	pubsub := newFakePubSub()
+	s := startSubscriber(pubsub)
+	defer s.close()
+
+	received := make(chan string, 4)
+	for _, channel := range []string{"room:1", "room:2", "game:node:a"} {
+		s.subscribe(channel, forward(received, channel))
+	}
+
+	pubsub.publish("room:3", "nobody")
+	pubsub.publish("room:2", "hello")
+	expectReceived(t, received, "room:2=hello")
+
+	stats := s.stats()
+	if stats.Channels != 3 || stats.ByPrefix["room"] != 2 || stats.ByPrefix["game"] != 1 {
+		t.Fatalf("unexpected subscription stats: %+v", stats)
+	}
+
+	s.setConnected(false)
+	s.setConnected(true)
+	if stats := s.stats(); !stats.Connected || stats.Reconnects != 1 {
+		t.Fatalf("expected one reconnect, got %+v", stats)
+	}
+}
+
+func TestSubscriberUnsubscribesEarlierChannelAfterLaterOne(t *testing.T) {
+	pubsub := newFakePubSub()
+	s := startSubscriber(pubsub)
+	defer s.close()
+
+	received := make(chan string, 4)
+	s.subscribe("room:1", forward(received, "room:1"))
+	s.subscribe("room:2", forward(received, "room:2"))
+	if err := s.unsubscribe("room:1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if subscribed := pubsub.subscribed(); len(subscribed) != 1 || !subscribed["room:2"] {
+		t.Fatalf("expected only room:2 to stay subscribed, got %v", subscribed)
+	}
+
+	pubsub.publish("room:1", "late")
+	pubsub.publish("room:2", "still here")
+	expectReceived(t, received, "room:2=still here")
+	if stats := s.stats(); stats.Channels != 1 {
+		t.Fatalf("expected one channel, got %+v", stats)
+	}
+}
+
+func TestSlowHandlerDoesNotBlockOtherChannels(t *testing.T) {
+	pubsub := newFakePubSub()
+	s := startSubscriber(pubsub)
+	defer s.close()
+
+	release := make(chan struct{})
+	received := make(chan string, 4)
+	s.subscribe("game:node:a", func(data []byte) {
+		<-release
+		received <- "game:node:a=" + string(data)
+	})
+	s.subscribe("room:1", forward(received, "room:1"))
+
+	pubsub.publish("game:node:a", "slow")
+	pubsub.publish("room:1", "fast")
+	expectReceived(t, received, "room:1=fast")
+
+	close(release)
+	expectReceived(t, received, "game:node:a=slow")
+}
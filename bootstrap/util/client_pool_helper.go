@@ -2,16 +2,16 @@ package util
 
 import "sync"
 
-func RegisterClient[T any](mu *sync.RWMutex, rooms map[uint]map[uint]T, roomID, userID uint, client T) {
+func RegisterClient[T any](mu *sync.RWMutex, rooms map[uint]map[string]T, roomID uint, userID string, client T) {
 	mu.Lock()
 	defer mu.Unlock()
 	if _, ok := rooms[roomID]; !ok {
-		rooms[roomID] = make(map[uint]T)
+		rooms[roomID] = make(map[string]T)
 	}
 	rooms[roomID][userID] = client
 }
 
-func UnregisterClient[T any](mu *sync.RWMutex, rooms map[uint]map[uint]T, roomID, userID uint) {
+func UnregisterClient[T any](mu *sync.RWMutex, rooms map[uint]map[string]T, roomID uint, userID string) {
 	mu.Lock()
 	defer mu.Unlock()
 	if clients, ok := rooms[roomID]; ok {
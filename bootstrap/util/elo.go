@@ -0,0 +1,34 @@
+package util
+
+import "math"
+
+const (
+	DefaultRating = 1200
+	EloKFactor    = 32
+)
+
+func CalculateEloChanges(ratings []int, placements []int) []int {
+	changes := make([]int, len(ratings))
+	if len(ratings) < 2 {
+		return changes
+	}
+
+	for i := range ratings {
+		delta := 0.0
+		for j := range ratings {
+			if i == j {
+				continue
+			}
+			expected := 1 / (1 + math.Pow(10, float64(ratings[j]-ratings[i])/400))
+			actual := 0.5
+			if placements[i] < placements[j] {
+				actual = 1
+			} else if placements[i] > placements[j] {
+				actual = 0
+			}
+			delta += actual - expected
+		}
+		changes[i] = int(math.Round(EloKFactor * delta / float64(len(ratings)-1)))
+	}
+	return changes
+}
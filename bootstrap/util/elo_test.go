@@ -0,0 +1,30 @@
+package util
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCalculateEloChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		ratings    []int
+		placements []int
+		want       []int
+	}{
+		{name: "single player is unrated", ratings: []int{1200}, placements: []int{0}, want: []int{0}},
+		{name: "even match", ratings: []int{1200, 1200}, placements: []int{0, 1}, want: []int{16, -16}},
+		{name: "draw between equals", ratings: []int{1200, 1200}, placements: []int{0, 0}, want: []int{0, 0}},
+		{name: "underdog wins", ratings: []int{1000, 1400}, placements: []int{0, 1}, want: []int{29, -29}},
+		{name: "favourite wins", ratings: []int{1400, 1000}, placements: []int{0, 1}, want: []int{3, -3}},
+		{name: "three way", ratings: []int{1200, 1200, 1200}, placements: []int{0, 1, 2}, want: []int{16, 0, -16}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateEloChanges(tt.ratings, tt.placements); !slices.Equal(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	userParticipantPrefix  = "user:"
+	guestParticipantPrefix = "guest:"
+	botParticipantPrefix   = "bot:"
+)
+
+func UserParticipantID(userID uint) string {
+	return fmt.Sprintf("%s%d", userParticipantPrefix, userID)
+}
+
+func GuestParticipantID(guestID string) string {
+	return guestParticipantPrefix + guestID
+}
+
+func BotParticipantID(botID string) string {
+	return botParticipantPrefix + botID
+}
+
+func IsBotParticipant(participantID string) bool {
+	return strings.HasPrefix(participantID, botParticipantPrefix)
+}
+
+func IsGuestParticipant(participantID string) bool {
+	return strings.HasPrefix(participantID, guestParticipantPrefix)
+}
+
+func ParseUserParticipantID(participantID string) (uint, bool) {
+	if !strings.HasPrefix(participantID, userParticipantPrefix) {
+		return 0, false
+	}
+	userID, err := strconv.ParseUint(strings.TrimPrefix(participantID, userParticipantPrefix), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return uint(userID), true
+}
+
+func ParseGuestParticipantID(participantID string) (string, bool) {
+	if !IsGuestParticipant(participantID) {
+		return "", false
+	}
+	return strings.TrimPrefix(participantID, guestParticipantPrefix), true
+}
+
+func MemberParticipantID(userID *uint, guestID *string) string {
+	if userID != nil {
+		return UserParticipantID(*userID)
+	}
+	if guestID != nil {
+		return GuestParticipantID(*guestID)
+	}
+	return ""
+}
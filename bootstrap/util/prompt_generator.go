@@ -0,0 +1,121 @@
+package util
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	MinPromptLength    = 2
+	MaxPromptLength    = 3
+	MinPromptSolutions = 20
+	PromptTiers        = 5
+	RoundsPerTier      = 2
+)
+
+type PromptGenerator struct {
+	counts map[string]int
+	tiers  [][]string
+	rng    *rand.Rand
+	mu     sync.Mutex
+}
+
+func NewPromptGenerator(words []string, seed int64) *PromptGenerator {
+	counts := CountSubstrings(words)
+
+	eligible := make([]string, 0, len(counts))
+	for prompt, count := range counts {
+		if count >= MinPromptSolutions {
+			eligible = append(eligible, prompt)
+		}
+	}
+
+	sort.Slice(eligible, func(i, j int) bool {
+		if counts[eligible[i]] != counts[eligible[j]] {
+			return counts[eligible[i]] > counts[eligible[j]]
+		}
+		return eligible[i] < eligible[j]
+	})
+
+	tierCount := min(PromptTiers, len(eligible))
+	tiers := make([][]string, tierCount)
+	for i := range tierCount {
+		start := i * len(eligible) / tierCount
+		end := (i + 1) * len(eligible) / tierCount
+		tiers[i] = eligible[start:end]
+	}
+
+	return &PromptGenerator{
+		counts: counts,
+		tiers:  tiers,
+		rng:    rand.New(rand.NewSource(seed)),
+	}
+}
+
+func CountSubstrings(words []string) map[string]int {
+	counts := make(map[string]int)
+	for _, word := range words {
+		runes := []rune(strings.ToLower(strings.TrimSpace(word)))
+		seen := make(map[string]struct{})
+		for length := MinPromptLength; length <= MaxPromptLength; length++ {
+			for start := 0; start+length <= len(runes); start++ {
+				part := runes[start : start+length]
+				if !isPromptCandidate(part) {
+					continue
+				}
+				seen[string(part)] = struct{}{}
+			}
+		}
+		for prompt := range seen {
+			counts[prompt]++
+		}
+	}
+	return counts
+}
+
+func isPromptCandidate(part []rune) bool {
+	if unicode.IsMark(part[0]) {
+		return false
+	}
+	for _, r := range part {
+		if !unicode.IsLetter(r) && !unicode.IsMark(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *PromptGenerator) Generate(round int) string {
+	if len(g.tiers) == 0 {
+		return ""
+	}
+
+	tier := g.tiers[g.TierForRound(round)]
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return tier[g.rng.Intn(len(tier))]
+}
+
+func (g *PromptGenerator) TierForRound(round int) int {
+	if len(g.tiers) == 0 {
+		return 0
+	}
+	tier := max(round-1, 0) / RoundsPerTier
+	return min(tier, len(g.tiers)-1)
+}
+
+func (g *PromptGenerator) Solutions(prompt string) int {
+	return g.counts[strings.ToLower(prompt)]
+}
+
+func (g *PromptGenerator) PromptCount() int {
+	count := 0
+	for _, tier := range g.tiers {
+		count += len(tier)
+	}
+	return count
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func testWords() []string {
+	rng := rand.New(rand.NewSource(1))
+	letters := []rune("abcdeilnorst")
+	words := make([]string, 0, 2000)
+	for range 2000 {
+		word := make([]rune, 4+rng.Intn(5))
+		for i := range word {
+			word[i] = letters[rng.Intn(len(letters))]
+		}
+		words = append(words, string(word))
+	}
+	return words
+}
+
+func TestPromptGeneratorIsDeterministicForASeed(t *testing.T) {
+	words := testWords()
+	first := NewPromptGenerator(words, 42)
+	second := NewPromptGenerator(words, 42)
+
+	a, b := make([]string, 0, 30), make([]string, 0, 30)
+	for round := 1; round <= 30; round++ {
+		a = append(a, first.Generate(round))
+		b = append(b, second.Generate(round))
+	}
+	if !slices.Equal(a, b) {
+		t.Fatalf("expected the same prompts for the same seed, got %v and %v", a, b)
+	}
+}
+
+func TestPromptTiersAdvanceEveryRoundsPerTier(t *testing.T) {
+	generator := NewPromptGenerator(testWords(), 1)
+	if len(generator.tiers) != PromptTiers {
+		t.Fatalf("expected %d tiers, got %d", PromptTiers, len(generator.tiers))
+	}
+
+	tests := []struct {
+		round int
+		want  int
+	}{
+		{round: 0, want: 0},
+		{round: 1, want: 0},
+		{round: RoundsPerTier, want: 0},
+		{round: RoundsPerTier + 1, want: 1},
+		{round: 2*RoundsPerTier + 1, want: 2},
+		{round: PromptTiers*RoundsPerTier + 1, want: PromptTiers - 1},
+		{round: 100, want: PromptTiers - 1},
+	}
+	for _, tt := range tests {
+		if got := generator.TierForRound(tt.round); got != tt.want {
+			t.Fatalf("round %d: expected tier %d, got %d", tt.round, tt.want, got)
+		}
+		if prompt := generator.Generate(tt.round); !slices.Contains(generator.tiers[tt.want], prompt) {
+			t.Fatalf("round %d: prompt %q is not in tier %d", tt.round, prompt, tt.want)
+		}
+	}
+}
+
+func TestEveryPromptHasEnoughSolutions(t *testing.T) {
+	generator := NewPromptGenerator(testWords(), 1)
+	if generator.PromptCount() == 0 {
+		t.Fatal("expected prompts to be generated")
+	}
+
+	previous := -1
+	for i, tier := range generator.tiers {
+		for _, prompt := range tier {
+			solutions := generator.Solutions(prompt)
+			if solutions < MinPromptSolutions {
+				t.Fatalf("prompt %q has %d solutions, want at least %d", prompt, solutions, MinPromptSolutions)
+			}
+			if previous >= 0 && solutions > previous {
+				t.Fatalf("tier %d prompt %q has more solutions than an easier prompt", i, prompt)
+			}
+			previous = solutions
+		}
+	}
+}
+
+func TestCountSubstringsCountsEachWordOnce(t *testing.T) {
+	counts := CountSubstrings([]string{"Banana", "band", "a-b"})
+	if counts["an"] != 2 || counts["ana"] != 1 || counts["ba"] != 2 {
+		t.Fatalf("unexpected counts %v", counts)
+	}
+	if _, exists := counts["a-"]; exists {
+		t.Fatal("expected prompts to contain letters only")
+	}
+}
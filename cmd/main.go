@@ -11,6 +11,8 @@ import (
 	"github.com/lakshya1goel/Playzio/bootstrap"
 	"github.com/lakshya1goel/Playzio/bootstrap/database"
 	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/repository"
+	"github.com/lakshya1goel/Playzio/usecase"
 	"github.com/lakshya1goel/Playzio/websocket"
 	"github.com/markbates/goth"
 	"github.com/markbates/goth/providers/google"
@@ -31,7 +33,7 @@ func main() {
 		),
 	)
 
-	app := bootstrap.App()
+	app := bootstrap.App(repository.NewRoomRepository(), usecase.NewGameUsecase(), usecase.NewChatUsecase(), usecase.NewModerationUsecase())
 	env := app.Env
 
 	database.ConnectDb(env)
@@ -40,9 +42,13 @@ func main() {
 	router := gin.Default()
 
 	authController := controller.NewAuthController()
-	gameController := controller.NewGameWSController(app.GamePool)
+	gameWsController := controller.NewGameWSController(app.GamePool)
 	chatController := controller.NewChatWSController(app.ChatPool, websocket.NewChatHandler())
 	roomController := controller.NewRoomController()
+	chatHistoryController := controller.NewChatController()
+	gameController := controller.NewGameController()
+	leaderboardController := controller.NewLeaderboardController()
+	monitoringController := controller.NewMonitoringController(app.RedisClient)
 
 	router.GET("/", func(c *gin.Context) {
 		c.JSON(200, gin.H{
@@ -52,8 +58,11 @@ func main() {
 	apiRouter := router.Group("/api")
 	{
 		routes.AuthRoutes(apiRouter, authController)
-		routes.WsRoutes(apiRouter, chatController, gameController)
-		routes.RoomRoutes(apiRouter, roomController)
+		routes.WsRoutes(apiRouter, chatController, gameWsController)
+		routes.RoomRoutes(apiRouter, roomController, chatHistoryController)
+		routes.GameRoutes(apiRouter, gameController)
+		routes.LeaderboardRoutes(apiRouter, leaderboardController)
+		routes.MonitoringRoutes(apiRouter, monitoringController)
 	}
 
 	router.Run(":8000")
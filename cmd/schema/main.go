@@ -0,0 +1,18 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lakshya1goel/Playzio/domain/protocol"
+)
+
+func main() {
+	schema, err := protocol.SchemaJSON()
+	if err != nil {
+		fmt.Println("Error generating protocol schema:", err)
+		os.Exit(1)
+	}
+
+	os.Stdout.Write(schema)
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/lakshya1goel/Playzio/domain/model"
+
+type ChatHistoryResponse struct {
+	Messages   []model.ChatMessage `json:"messages"`
+	NextCursor *uint               `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type LeaderboardFilter struct {
+	Since     *time.Time
+	RoomID    *uint
+	OnlyUsers bool
+	SortBy    string
+	Limit     int
+}
+
+type LeaderboardEntry struct {
+	Rank          int    `json:"rank"`
+	ParticipantID string `json:"participant_id"`
+	UserID        *uint  `json:"user_id,omitempty"`
+	UserName      string `json:"user_name"`
+	Wins          int    `json:"wins"`
+	GamesPlayed   int    `json:"games_played"`
+	TotalPoints   int    `json:"total_points"`
+	Rating        int    `json:"rating"`
+}
@@ -1,6 +1,24 @@
 package dto
 
 type CreateRoomRequest struct {
-	Name string `json:"name" binding:"required"`
-	Type string `json:"type" binding:"required"`
+	Name     string               `json:"name" binding:"required"`
+	Type     string               `json:"type" binding:"required"`
+	Language string               `json:"language"`
+	Settings *GameSettingsRequest `json:"settings"`
+}
+
+type GameSettingsRequest struct {
+	Lives             *int    `json:"lives"`
+	LifeCap           *int    `json:"life_cap"`
+	MaxTurnTime       *int    `json:"max_turn_time"`
+	MinTurnTime       *int    `json:"min_turn_time"`
+	TurnTimeDecay     *int    `json:"turn_time_decay"`
+	MinWordLength     *int    `json:"min_word_length"`
+	MaxPlayers        *int    `json:"max_players"`
+	MinPlayersToStart *int    `json:"min_players_to_start"`
+	Scoring           *string `json:"scoring"`
+	Mode              *string `json:"mode"`
+	TeamCount         *int    `json:"team_count"`
+	RaceWinners       *int    `json:"race_winners"`
+	RotateStart       *bool   `json:"rotate_start"`
 }
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Game struct {
+	gorm.Model
+	RoomID       uint         `json:"room_id" gorm:"index"`
+	Language     string       `json:"language"`
+	Mode         string       `json:"mode" gorm:"default:free_for_all"`
+	WinnerID     string       `json:"winner_id"`
+	WinningTeam  *int         `json:"winning_team,omitempty"`
+	RoundsPlayed int          `json:"rounds_played"`
+	StartedAt    time.Time    `json:"started_at"`
+	EndedAt      time.Time    `json:"ended_at"`
+	Players      []GamePlayer `gorm:"foreignKey:GameID" json:"players,omitempty"`
+	Turns        []GameTurn   `gorm:"foreignKey:GameID" json:"turns,omitempty"`
+}
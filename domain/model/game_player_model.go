@@ -0,0 +1,21 @@
+package model
+
+import "gorm.io/gorm"
+
+type GamePlayer struct {
+	gorm.Model
+	GameID        uint    `json:"game_id" gorm:"index"`
+	ParticipantID string  `json:"participant_id" gorm:"index"`
+	UserID        *uint   `json:"user_id,omitempty" gorm:"index"`
+	GuestID       *string `json:"guest_id,omitempty"`
+	IsBot         bool    `json:"is_bot"`
+	UserName      string  `json:"user_name"`
+	Team          *int    `json:"team,omitempty"`
+	IsWinner      bool    `json:"is_winner"`
+	WordsAnswered int     `json:"words_answered"`
+	LivesLost     int     `json:"lives_lost"`
+	FinalLives    int     `json:"final_lives"`
+	FinalPoints   int     `json:"final_points"`
+	Rating        int     `json:"rating"`
+	RatingChange  int     `json:"rating_change"`
+}
@@ -4,17 +4,46 @@ import "time"
 
 type GameRoomState struct {
 	RoomID           uint
-	CreatedBy        uint
-	Players          []uint
-	Lives            map[uint]int
-	Points           map[uint]int
+	CreatedBy        string
+	Language         string
+	Settings         GameSettings
+	Players          []string
+	PlayerNames      map[string]string
+	Lives            map[string]int
+	Points           map[string]int
+	ScoreBreakdowns  map[string]ScoreBreakdown
+	WordsAnswered    map[string]int
+	LivesLost        map[string]int
+	LettersUsed      map[string]map[string]bool
+	Teams            map[string]int
+	TeamLives        map[int]int
+	TeamTurn         int
+	TeamCursors      map[int]int
+	WinningTeam      int
+	RoundAnswers     map[string]string
+	Turns            []GameTurn
 	TurnIndex        int
+	StartingTurn     int
+	TurnID           int
+	TurnTimer        *time.Timer
+	TurnEndsAt       time.Time
 	CharSet          string
 	Started          bool
+	Finished         bool
+	StartedAt        time.Time
 	Round            int
 	TimeLimit        int
-	WinnerID         uint
+	WinnerID         string
+	SeriesGames      int
+	SeriesWins       map[string]int
+	RematchVotes     map[string]bool
+	UsedWords        []string
+	UsedWordSet      map[string]bool
 	CountdownStarted bool
+	CountdownID      int
 	CountdownEndTime time.Time
 	CountdownTimer   *time.Timer
+	ResumeTokens     map[string]string
+	Disconnected     map[string]time.Time
+	DisconnectTimers map[string]*time.Timer
 }
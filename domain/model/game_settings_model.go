@@ -0,0 +1,70 @@
+package model
+
+const (
+	ModeFreeForAll = "free_for_all"
+	ModeTeams      = "teams"
+	ModeRace       = "race"
+)
+
+const NoTeam = -1
+
+const (
+	DefaultLives             = 3
+	DefaultLifeCap           = 3
+	DefaultMaxTurnTime       = 20
+	DefaultMinTurnTime       = 5
+	DefaultTurnTimeDecay     = 1
+	DefaultMinWordLength     = 1
+	DefaultMaxPlayers        = 10
+	DefaultMinPlayersToStart = 2
+	DefaultScoring           = ScoringWeighted
+	DefaultMode              = ModeFreeForAll
+	DefaultTeamCount         = 2
+	DefaultRaceWinners       = 3
+)
+
+const (
+	MinLives         = 1
+	MaxLives         = 10
+	MinTurnTime      = 3
+	MaxTurnTime      = 60
+	MaxTurnTimeDecay = 10
+	MaxMinWordLength = 15
+	MinPlayers       = 2
+	MaxPlayers       = 20
+	MinTeams         = 2
+	MaxTeams         = 4
+)
+
+type GameSettings struct {
+	Lives             int    `json:"lives" gorm:"default:3"`
+	LifeCap           int    `json:"life_cap" gorm:"default:3"`
+	MaxTurnTime       int    `json:"max_turn_time" gorm:"default:20"`
+	MinTurnTime       int    `json:"min_turn_time" gorm:"default:5"`
+	TurnTimeDecay     int    `json:"turn_time_decay"`
+	MinWordLength     int    `json:"min_word_length" gorm:"default:1"`
+	MaxPlayers        int    `json:"max_players" gorm:"default:10"`
+	MinPlayersToStart int    `json:"min_players_to_start" gorm:"default:2"`
+	Scoring           string `json:"scoring" gorm:"default:weighted"`
+	Mode              string `json:"mode" gorm:"default:free_for_all"`
+	TeamCount         int    `json:"team_count" gorm:"default:2"`
+	RaceWinners       int    `json:"race_winners" gorm:"default:3"`
+	RotateStart       bool   `json:"rotate_start"`
+}
+
+func DefaultGameSettings() GameSettings {
+	return GameSettings{
+		Lives:             DefaultLives,
+		LifeCap:           DefaultLifeCap,
+		MaxTurnTime:       DefaultMaxTurnTime,
+		MinTurnTime:       DefaultMinTurnTime,
+		TurnTimeDecay:     DefaultTurnTimeDecay,
+		MinWordLength:     DefaultMinWordLength,
+		MaxPlayers:        DefaultMaxPlayers,
+		MinPlayersToStart: DefaultMinPlayersToStart,
+		Scoring:           DefaultScoring,
+		Mode:              DefaultMode,
+		TeamCount:         DefaultTeamCount,
+		RaceWinners:       DefaultRaceWinners,
+	}
+}
@@ -0,0 +1,14 @@
+package model
+
+import "gorm.io/gorm"
+
+type GameTurn struct {
+	gorm.Model
+	GameID        uint   `json:"game_id" gorm:"index"`
+	TurnNumber    int    `json:"turn_number"`
+	Round         int    `json:"round"`
+	ParticipantID string `json:"participant_id"`
+	CharSet       string `json:"char_set"`
+	Answer        string `json:"answer,omitempty"`
+	Result        string `json:"result"`
+}
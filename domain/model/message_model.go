@@ -8,33 +8,107 @@ type ChatMessage struct {
 	gorm.Model
 	Type   string `json:"type"`
 	Body   string `json:"body"`
-	Sender uint   `json:"sender"`
-	RoomID uint   `json:"room_id"`
+	Sender string `json:"sender"`
+	RoomID uint   `json:"room_id" gorm:"index"`
+	Target string `json:"target,omitempty" gorm:"-"`
 }
 
 const (
-	JoinRoom    = "join-room"
-	LeaveRoom   = "leave-room"
-	ChatContent = "chat-content"
+	JoinRoom      = "join-room"
+	LeaveRoom     = "leave-room"
+	ChatContent   = "chat-content"
+	MuteMember    = "mute-member"
+	UnmuteMember  = "unmute-member"
+	KickMember    = "kick-member"
+	MemberMuted   = "member-muted"
+	MemberUnmuted = "member-unmuted"
+	MemberKicked  = "member-kicked"
 )
 
 type GameMessage struct {
-	Type    string         `json:"type"`
-	Payload map[string]any `json:"payload,omitempty"`
+	Type    string `json:"type"`
+	Payload any    `json:"payload,omitempty"`
 }
 
 const (
-	Join         = "join"
-	Answer       = "answer"
-	Leave        = "leave"
-	Typing       = "typing"
-	TimerStarted = "timer_started"
-	StartGame    = "start_game"
-	NextTurn     = "next_turn"
-	GameOver     = "game_over"
-	UserJoined   = "user_joined"
-	UserLeft     = "user_left"
-	TurnEnded    = "turn_ended"
-	Ping         = "ping"
-	Pong         = "pong"
+	Join               = "join"
+	Answer             = "answer"
+	Leave              = "leave"
+	Typing             = "typing"
+	TimerStarted       = "timer_started"
+	StartGame          = "start_game"
+	NextTurn           = "next_turn"
+	GameOver           = "game_over"
+	UserJoined         = "user_joined"
+	UserLeft           = "user_left"
+	TurnEnded          = "turn_ended"
+	Ping               = "ping"
+	Pong               = "pong"
+	Error              = "error"
+	ResumeToken        = "resume_token"
+	Resync             = "resync"
+	UserDisconnected   = "user_disconnected"
+	UserReconnected    = "user_reconnected"
+	CancelCountdown    = "cancel_countdown"
+	ExtendCountdown    = "extend_countdown"
+	CountdownCancelled = "countdown_cancelled"
+	LifeGained         = "life_gained"
+	JoinTeam           = "join_team"
+	TeamChanged        = "team_changed"
+	RoundEnded         = "round_ended"
+	AddBot             = "add_bot"
+	RemoveBot          = "remove_bot"
+	Rematch            = "rematch"
+	RematchVote        = "rematch_vote"
+	RematchStarted     = "rematch_started"
+	Welcome            = "welcome"
+)
+
+const (
+	ReasonTimeout          = "timeout"
+	ReasonCorrectAnswer    = "correct_answer"
+	ReasonWrongAnswer      = "wrong_answer"
+	ReasonMissingCharSet   = "missing_char_set"
+	ReasonInvalidWord      = "invalid_word"
+	ReasonAlreadyUsed      = "already_used"
+	ReasonTooShort         = "too_short"
+	ReasonHostCancelled    = "host_cancelled"
+	ReasonNotEnoughPlayers = "not_enough_players"
+)
+
+const (
+	RolePlayer    = "player"
+	RoleSpectator = "spectator"
+)
+
+const (
+	ErrorNotYourTurn        = "not_your_turn"
+	ErrorInvalidResumeToken = "invalid_resume_token"
+	ErrorNotJoined          = "not_joined"
+	ErrorRoomFull           = "room_full"
+	ErrorSpectatorsFull     = "spectators_full"
+	ErrorSpectator          = "spectator"
+	ErrorNotHost            = "not_host"
+	ErrorNotEnoughPlayers   = "not_enough_players"
+	ErrorGameInProgress     = "game_in_progress"
+	ErrorGameFinished       = "game_finished"
+	ErrorNoCountdown        = "no_countdown"
+	ErrorNotTeamMode        = "not_team_mode"
+	ErrorInvalidTeam        = "invalid_team"
+	ErrorEliminated         = "eliminated"
+	ErrorAlreadyAnswered    = "already_answered"
+	ErrorInvalidDifficulty  = "invalid_difficulty"
+	ErrorBotNotFound        = "bot_not_found"
+	ErrorGameNotFinished    = "game_not_finished"
+	ErrorGameNotStarted     = "game_not_started"
+	ErrorInvalidPayload     = "invalid_payload"
+	ErrorUnknownMessage     = "unknown_message_type"
+	ErrorRateLimited        = "rate_limited"
+	ErrorMuted              = "muted"
+	ErrorKicked             = "kicked"
+	ErrorMemberNotFound     = "member_not_found"
+	ErrorMessageTooLong     = "message_too_long"
+	ErrorLinkNotAllowed     = "link_not_allowed"
+	ErrorModerationFailed   = "moderation_failed"
+	ErrorRoomMoved          = "room_moved"
 )
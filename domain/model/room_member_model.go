@@ -11,4 +11,6 @@ type RoomMember struct {
 	GuestID   *string `json:"guest_id,omitempty"`
 	GuestName *string `json:"guest_name,omitempty"`
 	IsCreator bool    `json:"is_creator"`
+	IsMuted   bool    `json:"is_muted"`
+	IsKicked  bool    `json:"is_kicked"`
 }
@@ -6,8 +6,10 @@ type Room struct {
 	gorm.Model
 	Name           string       `json:"name"`
 	Type           string       `json:"type"`
+	Language       string       `json:"language" gorm:"default:en"`
 	CreatedBy      *uint        `json:"created_by,omitempty"`
 	JoinCode       string       `json:"join_code"`
 	CreatorGuestID *string      `json:"creator_guest_id,omitempty"`
+	Settings       GameSettings `json:"settings" gorm:"embedded;embeddedPrefix:setting_"`
 	Members        []RoomMember `gorm:"foreignKey:RoomID" json:"members,omitempty"`
 }
@@ -0,0 +1,26 @@
+package model
+
+const (
+	ScoringFlat     = "flat"
+	ScoringWeighted = "weighted"
+)
+
+type ScoreBreakdown struct {
+	Base       int `json:"base"`
+	Length     int `json:"length"`
+	Difficulty int `json:"difficulty"`
+	Speed      int `json:"speed"`
+	Rarity     int `json:"rarity"`
+	Total      int `json:"total"`
+}
+
+func (s ScoreBreakdown) Add(other ScoreBreakdown) ScoreBreakdown {
+	return ScoreBreakdown{
+		Base:       s.Base + other.Base,
+		Length:     s.Length + other.Length,
+		Difficulty: s.Difficulty + other.Difficulty,
+		Speed:      s.Speed + other.Speed,
+		Rarity:     s.Rarity + other.Rarity,
+		Total:      s.Total + other.Total,
+	}
+}
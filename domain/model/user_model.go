@@ -7,4 +7,5 @@ type User struct {
 	Name       string  `json:"name"`
 	Email      string  `json:"email" gorm:"unique"`
 	ProfilePic *string `json:"profile_pic"`
+	Rating     int     `json:"rating" gorm:"default:1200"`
 }
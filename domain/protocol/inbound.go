@@ -0,0 +1,199 @@
+package protocol
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+type JoinRequest struct {
+	RoomID      uint   `json:"room_id"`
+	ResumeToken string `json:"resume_token,omitempty"`
+	Role        string `json:"role,omitempty"`
+}
+
+func (JoinRequest) MessageType() string {
+	return model.Join
+}
+
+func (r *JoinRequest) Validate() error {
+	if r.RoomID == 0 {
+		return errors.New("room_id is required")
+	}
+	if r.Role != "" && r.Role != model.RolePlayer && r.Role != model.RoleSpectator {
+		return errors.New("role must be player or spectator")
+	}
+	return nil
+}
+
+type AnswerRequest struct {
+	Answer string `json:"answer"`
+}
+
+func (AnswerRequest) MessageType() string {
+	return model.Answer
+}
+
+func (r *AnswerRequest) Validate() error {
+	if strings.TrimSpace(r.Answer) == "" {
+		return errors.New("answer is required")
+	}
+	return nil
+}
+
+type LeaveRequest struct{}
+
+func (LeaveRequest) MessageType() string {
+	return model.Leave
+}
+
+func (r *LeaveRequest) Validate() error {
+	return nil
+}
+
+type ResyncRequest struct{}
+
+func (ResyncRequest) MessageType() string {
+	return model.Resync
+}
+
+func (r *ResyncRequest) Validate() error {
+	return nil
+}
+
+type StartGameRequest struct {
+	Duration *int `json:"duration,omitempty"`
+}
+
+func (StartGameRequest) MessageType() string {
+	return model.StartGame
+}
+
+func (r *StartGameRequest) Validate() error {
+	return validateDuration(r.Duration)
+}
+
+type CancelCountdownRequest struct{}
+
+func (CancelCountdownRequest) MessageType() string {
+	return model.CancelCountdown
+}
+
+func (r *CancelCountdownRequest) Validate() error {
+	return nil
+}
+
+type ExtendCountdownRequest struct {
+	Duration *int `json:"duration,omitempty"`
+}
+
+func (ExtendCountdownRequest) MessageType() string {
+	return model.ExtendCountdown
+}
+
+func (r *ExtendCountdownRequest) Validate() error {
+	return validateDuration(r.Duration)
+}
+
+type JoinTeamRequest struct {
+	Team *int `json:"team"`
+}
+
+func (JoinTeamRequest) MessageType() string {
+	return model.JoinTeam
+}
+
+func (r *JoinTeamRequest) Validate() error {
+	if r.Team == nil {
+		return errors.New("team is required")
+	}
+	if *r.Team < 0 {
+		return errors.New("team must not be negative")
+	}
+	return nil
+}
+
+type AddBotRequest struct {
+	Difficulty string `json:"difficulty,omitempty"`
+}
+
+func (AddBotRequest) MessageType() string {
+	return model.AddBot
+}
+
+func (r *AddBotRequest) Validate() error {
+	return nil
+}
+
+type RemoveBotRequest struct {
+	UserID string `json:"user_id"`
+}
+
+func (RemoveBotRequest) MessageType() string {
+	return model.RemoveBot
+}
+
+func (r *RemoveBotRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("user_id is required")
+	}
+	return nil
+}
+
+type RematchRequest struct{}
+
+func (RematchRequest) MessageType() string {
+	return model.Rematch
+}
+
+func (r *RematchRequest) Validate() error {
+	return nil
+}
+
+type TypingRequest struct {
+	Text string `json:"text"`
+}
+
+func (TypingRequest) MessageType() string {
+	return model.Typing
+}
+
+func (r *TypingRequest) Validate() error {
+	if len(r.Text) > MaxTypingLength {
+		return errors.New("text is too long")
+	}
+	return nil
+}
+
+type PingRequest struct {
+	Timestamp int64 `json:"timestamp"`
+}
+
+func (PingRequest) MessageType() string {
+	return model.Ping
+}
+
+func (r *PingRequest) Validate() error {
+	return nil
+}
+
+type PongRequest struct {
+	Timestamp int64 `json:"timestamp"`
+	PingID    int   `json:"ping_id,omitempty"`
+}
+
+func (PongRequest) MessageType() string {
+	return model.Pong
+}
+
+func (r *PongRequest) Validate() error {
+	return nil
+}
+
+func validateDuration(duration *int) error {
+	if duration != nil && *duration < 0 {
+		return errors.New("duration must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+package protocol
+
+import "github.com/lakshya1goel/Playzio/domain/model"
+
+type WelcomeEvent struct {
+	UserID            string `json:"user_id"`
+	ProtocolVersion   int    `json:"protocol_version"`
+	SupportedVersions []int  `json:"supported_versions"`
+}
+
+func (WelcomeEvent) MessageType() string {
+	return model.Welcome
+}
+
+type PingEvent struct {
+	Timestamp int64 `json:"timestamp"`
+	PingID    int   `json:"ping_id"`
+}
+
+func (PingEvent) MessageType() string {
+	return model.Ping
+}
+
+type PongEvent struct {
+	Timestamp int64 `json:"timestamp"`
+}
+
+func (PongEvent) MessageType() string {
+	return model.Pong
+}
+
+type ErrorEvent struct {
+	RoomID  uint   `json:"room_id"`
+	UserID  string `json:"user_id"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (ErrorEvent) MessageType() string {
+	return model.Error
+}
+
+type TimerStartedEvent struct {
+	RoomID   uint `json:"room_id"`
+	Duration int  `json:"duration"`
+}
+
+func (TimerStartedEvent) MessageType() string {
+	return model.TimerStarted
+}
+
+type CountdownCancelledEvent struct {
+	RoomID uint   `json:"room_id"`
+	Reason string `json:"reason"`
+}
+
+func (CountdownCancelledEvent) MessageType() string {
+	return model.CountdownCancelled
+}
+
+type UserJoinedEvent struct {
+	RoomID   uint   `json:"room_id"`
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name"`
+	Role     string `json:"role"`
+	HostID   string `json:"host_id,omitempty"`
+}
+
+func (UserJoinedEvent) MessageType() string {
+	return model.UserJoined
+}
+
+type UserLeftEvent struct {
+	RoomID   uint   `json:"room_id"`
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name"`
+	Role     string `json:"role"`
+}
+
+func (UserLeftEvent) MessageType() string {
+	return model.UserLeft
+}
+
+type UserDisconnectedEvent struct {
+	RoomID      uint   `json:"room_id"`
+	UserID      string `json:"user_id"`
+	UserName    string `json:"user_name"`
+	GracePeriod int    `json:"grace_period"`
+}
+
+func (UserDisconnectedEvent) MessageType() string {
+	return model.UserDisconnected
+}
+
+type UserReconnectedEvent struct {
+	RoomID   uint   `json:"room_id"`
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name"`
+}
+
+func (UserReconnectedEvent) MessageType() string {
+	return model.UserReconnected
+}
+
+type ResumeTokenEvent struct {
+	RoomID      uint   `json:"room_id"`
+	UserID      string `json:"user_id"`
+	ResumeToken string `json:"resume_token"`
+	GracePeriod int    `json:"grace_period"`
+}
+
+func (ResumeTokenEvent) MessageType() string {
+	return model.ResumeToken
+}
+
+type ResyncEvent struct {
+	RoomID        uint           `json:"room_id"`
+	UserID        string         `json:"user_id"`
+	Started       bool           `json:"started"`
+	HostID        string         `json:"host_id"`
+	Duration      int            `json:"duration"`
+	Players       []string       `json:"players"`
+	CharSet       string         `json:"char_set"`
+	Round         int            `json:"round"`
+	TimeLimit     int            `json:"time_limit"`
+	TurnUserID    string         `json:"turn_user_id"`
+	RemainingTime int            `json:"remaining_time"`
+	PlayerLives   map[string]int `json:"player_lives"`
+	PlayerScores  map[string]int `json:"player_scores"`
+	UsedWords     []string       `json:"used_words"`
+	SeriesGames   int            `json:"series_games"`
+	SeriesWins    map[string]int `json:"series_wins"`
+	Teams         map[string]int `json:"teams,omitempty"`
+}
+
+func (ResyncEvent) MessageType() string {
+	return model.Resync
+}
+
+type TeamChangedEvent struct {
+	RoomID uint   `json:"room_id"`
+	UserID string `json:"user_id"`
+	Team   int    `json:"team"`
+}
+
+func (TeamChangedEvent) MessageType() string {
+	return model.TeamChanged
+}
+
+type RematchVoteEvent struct {
+	RoomID      uint   `json:"room_id"`
+	UserID      string `json:"user_id"`
+	Votes       int    `json:"votes"`
+	VotesNeeded int    `json:"votes_needed"`
+}
+
+func (RematchVoteEvent) MessageType() string {
+	return model.RematchVote
+}
+
+type RematchStartedEvent struct {
+	RoomID      uint           `json:"room_id"`
+	UserID      string         `json:"user_id"`
+	SeriesGames int            `json:"series_games"`
+	SeriesWins  map[string]int `json:"series_wins"`
+}
+
+func (RematchStartedEvent) MessageType() string {
+	return model.RematchStarted
+}
+
+type StartGameEvent struct {
+	RoomID    uint           `json:"room_id"`
+	CharSet   string         `json:"char_set"`
+	Round     int            `json:"round"`
+	TimeLimit int            `json:"time_limit"`
+	Teams     map[string]int `json:"teams,omitempty"`
+}
+
+func (StartGameEvent) MessageType() string {
+	return model.StartGame
+}
+
+type NextTurnEvent struct {
+	RoomID           uint           `json:"room_id"`
+	UserID           string         `json:"user_id,omitempty"`
+	CharSet          string         `json:"char_set"`
+	TimeLimit        int            `json:"time_limit"`
+	Round            int            `json:"round"`
+	Lives            int            `json:"lives,omitempty"`
+	LettersUsed      []string       `json:"letters_used,omitempty"`
+	LettersRemaining []string       `json:"letters_remaining,omitempty"`
+	Team             *int           `json:"team,omitempty"`
+	PlayerLives      map[string]int `json:"player_lives,omitempty"`
+}
+
+func (NextTurnEvent) MessageType() string {
+	return model.NextTurn
+}
+
+type TurnEndedEvent struct {
+	RoomID           uint     `json:"room_id"`
+	UserID           string   `json:"user_id"`
+	Reason           string   `json:"reason"`
+	Lives            int      `json:"lives"`
+	Round            int      `json:"round"`
+	Score            int      `json:"score"`
+	LettersUsed      []string `json:"letters_used"`
+	LettersRemaining []string `json:"letters_remaining"`
+}
+
+func (TurnEndedEvent) MessageType() string {
+	return model.TurnEnded
+}
+
+type AnswerEvent struct {
+	RoomID         uint                  `json:"room_id"`
+	UserID         string                `json:"user_id"`
+	Answer         string                `json:"answer"`
+	Correct        bool                  `json:"correct"`
+	Reason         string                `json:"reason,omitempty"`
+	ScoreBreakdown *model.ScoreBreakdown `json:"score_breakdown,omitempty"`
+	CharSet        string                `json:"char_set"`
+	Score          int                   `json:"score"`
+	Lives          int                   `json:"lives"`
+}
+
+func (AnswerEvent) MessageType() string {
+	return model.Answer
+}
+
+type LifeGainedEvent struct {
+	RoomID uint   `json:"room_id"`
+	UserID string `json:"user_id"`
+	Lives  int    `json:"lives"`
+}
+
+func (LifeGainedEvent) MessageType() string {
+	return model.LifeGained
+}
+
+type RoundEndedEvent struct {
+	RoomID       uint              `json:"room_id"`
+	Round        int               `json:"round"`
+	CharSet      string            `json:"char_set"`
+	Answers      map[string]string `json:"answers"`
+	PlayerLives  map[string]int    `json:"player_lives"`
+	PlayerScores map[string]int    `json:"player_scores"`
+}
+
+func (RoundEndedEvent) MessageType() string {
+	return model.RoundEnded
+}
+
+type FinalScore struct {
+	Points    int                  `json:"points"`
+	Lives     int                  `json:"lives"`
+	Breakdown model.ScoreBreakdown `json:"breakdown"`
+	Team      *int                 `json:"team,omitempty"`
+}
+
+type TeamResult struct {
+	Team    int      `json:"team"`
+	Players []string `json:"players"`
+	Points  int      `json:"points"`
+	Lives   int      `json:"lives"`
+	Winner  bool     `json:"winner"`
+}
+
+type GameOverEvent struct {
+	RoomID        uint                  `json:"room_id"`
+	GameID        uint                  `json:"game_id"`
+	WinnerID      string                `json:"winner_id"`
+	FinalScores   map[string]FinalScore `json:"final_scores"`
+	UsedWords     []string              `json:"used_words"`
+	RatingChanges map[string]int        `json:"rating_changes"`
+	SeriesGames   int                   `json:"series_games"`
+	SeriesWins    map[string]int        `json:"series_wins"`
+	WinningTeam   *int                  `json:"winning_team,omitempty"`
+	TeamResults   []TeamResult          `json:"team_results,omitempty"`
+}
+
+func (GameOverEvent) MessageType() string {
+	return model.GameOver
+}
+
+type TypingEvent struct {
+	RoomID uint   `json:"room_id"`
+	UserID string `json:"user_id"`
+	Text   string `json:"text"`
+}
+
+func (TypingEvent) MessageType() string {
+	return model.Typing
+}
@@ -0,0 +1,152 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+const Version = 1
+
+const MaxTypingLength = 64
+
+var SupportedVersions = []int{1}
+
+type InboundPayload interface {
+	MessageType() string
+	Validate() error
+}
+
+type OutboundPayload interface {
+	MessageType() string
+}
+
+var inbound = map[string]func() InboundPayload{
+	model.Join:            func() InboundPayload { return &JoinRequest{} },
+	model.Answer:          func() InboundPayload { return &AnswerRequest{} },
+	model.Leave:           func() InboundPayload { return &LeaveRequest{} },
+	model.Resync:          func() InboundPayload { return &ResyncRequest{} },
+	model.StartGame:       func() InboundPayload { return &StartGameRequest{} },
+	model.CancelCountdown: func() InboundPayload { return &CancelCountdownRequest{} },
+	model.ExtendCountdown: func() InboundPayload { return &ExtendCountdownRequest{} },
+	model.JoinTeam:        func() InboundPayload { return &JoinTeamRequest{} },
+	model.AddBot:          func() InboundPayload { return &AddBotRequest{} },
+	model.RemoveBot:       func() InboundPayload { return &RemoveBotRequest{} },
+	model.Rematch:         func() InboundPayload { return &RematchRequest{} },
+	model.Typing:          func() InboundPayload { return &TypingRequest{} },
+	model.Ping:            func() InboundPayload { return &PingRequest{} },
+	model.Pong:            func() InboundPayload { return &PongRequest{} },
+}
+
+var outbound = map[string]func() OutboundPayload{
+	model.Welcome:            func() OutboundPayload { return &WelcomeEvent{} },
+	model.Ping:               func() OutboundPayload { return &PingEvent{} },
+	model.Pong:               func() OutboundPayload { return &PongEvent{} },
+	model.Error:              func() OutboundPayload { return &ErrorEvent{} },
+	model.TimerStarted:       func() OutboundPayload { return &TimerStartedEvent{} },
+	model.CountdownCancelled: func() OutboundPayload { return &CountdownCancelledEvent{} },
+	model.UserJoined:         func() OutboundPayload { return &UserJoinedEvent{} },
+	model.UserLeft:           func() OutboundPayload { return &UserLeftEvent{} },
+	model.UserDisconnected:   func() OutboundPayload { return &UserDisconnectedEvent{} },
+	model.UserReconnected:    func() OutboundPayload { return &UserReconnectedEvent{} },
+	model.ResumeToken:        func() OutboundPayload { return &ResumeTokenEvent{} },
+	model.Resync:             func() OutboundPayload { return &ResyncEvent{} },
+	model.TeamChanged:        func() OutboundPayload { return &TeamChangedEvent{} },
+	model.RematchVote:        func() OutboundPayload { return &RematchVoteEvent{} },
+	model.RematchStarted:     func() OutboundPayload { return &RematchStartedEvent{} },
+	model.StartGame:          func() OutboundPayload { return &StartGameEvent{} },
+	model.NextTurn:           func() OutboundPayload { return &NextTurnEvent{} },
+	model.TurnEnded:          func() OutboundPayload { return &TurnEndedEvent{} },
+	model.Answer:             func() OutboundPayload { return &AnswerEvent{} },
+	model.LifeGained:         func() OutboundPayload { return &LifeGainedEvent{} },
+	model.RoundEnded:         func() OutboundPayload { return &RoundEndedEvent{} },
+	model.GameOver:           func() OutboundPayload { return &GameOverEvent{} },
+	model.Typing:             func() OutboundPayload { return &TypingEvent{} },
+}
+
+type DecodeError struct {
+	Code    string
+	Message string
+}
+
+func (e *DecodeError) Error() string {
+	return e.Message
+}
+
+type envelope struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+func NewMessage(payload OutboundPayload) model.GameMessage {
+	return model.GameMessage{
+		Type:    payload.MessageType(),
+		Payload: payload,
+	}
+}
+
+func Negotiate(requested string) (int, bool) {
+	if requested == "" {
+		return Version, true
+	}
+
+	version, err := strconv.Atoi(requested)
+	if err != nil || !slices.Contains(SupportedVersions, version) {
+		return 0, false
+	}
+	return version, true
+}
+
+func DecodeInbound(data []byte) (InboundPayload, *DecodeError) {
+	payload, decodeErr := decode(data, inbound)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	if err := payload.Validate(); err != nil {
+		return nil, &DecodeError{Code: model.ErrorInvalidPayload, Message: err.Error()}
+	}
+	return payload, nil
+}
+
+func DecodeOutbound(data []byte) (OutboundPayload, *DecodeError) {
+	return decode(data, outbound)
+}
+
+func decode[T any](data []byte, registry map[string]func() T) (T, *DecodeError) {
+	var zero T
+
+	var msg envelope
+	if err := decodeStrict(data, &msg); err != nil {
+		return zero, &DecodeError{Code: model.ErrorInvalidPayload, Message: fmt.Sprintf("invalid message: %v", err)}
+	}
+
+	newPayload, exists := registry[msg.Type]
+	if !exists {
+		return zero, &DecodeError{Code: model.ErrorUnknownMessage, Message: fmt.Sprintf("Unknown message type %q", msg.Type)}
+	}
+
+	payload := newPayload()
+	if len(msg.Payload) > 0 && !bytes.Equal(msg.Payload, []byte("null")) {
+		if err := decodeStrict(msg.Payload, payload); err != nil {
+			return zero, &DecodeError{Code: model.ErrorInvalidPayload, Message: fmt.Sprintf("invalid %s payload: %v", msg.Type, err)}
+		}
+	}
+	return payload, nil
+}
+
+func decodeStrict(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("unexpected data after message")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package protocol
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+func TestSchemaFileIsUpToDate(t *testing.T) {
+	expected, err := SchemaJSON()
+	if err != nil {
+		t.Fatalf("failed to generate schema: %v", err)
+	}
+
+	committed, err := os.ReadFile("schema.json")
+	if err != nil {
+		t.Fatalf("failed to read schema.json: %v", err)
+	}
+	if !bytes.Equal(committed, expected) {
+		t.Fatal("schema.json is stale, regenerate it with: go run ./cmd/schema > domain/protocol/schema.json")
+	}
+}
+
+func TestDecodeInbound(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		code string
+	}{
+		{name: "join", data: `{"type":"join","payload":{"room_id":5,"role":"spectator"}}`},
+		{name: "payload omitted", data: `{"type":"leave"}`},
+		{name: "malformed json", data: `{"type":`, code: model.ErrorInvalidPayload},
+		{name: "unknown type", data: `{"type":"dance"}`, code: model.ErrorUnknownMessage},
+		{name: "unknown field", data: `{"type":"answer","payload":{"answer":"cat","extra":1}}`, code: model.ErrorInvalidPayload},
+		{name: "wrong field type", data: `{"type":"answer","payload":{"answer":42}}`, code: model.ErrorInvalidPayload},
+		{name: "trailing data", data: `{"type":"leave"}{"type":"leave"}`, code: model.ErrorInvalidPayload},
+		{name: "missing room", data: `{"type":"join","payload":{}}`, code: model.ErrorInvalidPayload},
+		{name: "invalid role", data: `{"type":"join","payload":{"room_id":5,"role":"referee"}}`, code: model.ErrorInvalidPayload},
+		{name: "missing team", data: `{"type":"join_team","payload":{}}`, code: model.ErrorInvalidPayload},
+		{name: "negative duration", data: `{"type":"start_game","payload":{"duration":-1}}`, code: model.ErrorInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := DecodeInbound([]byte(tt.data))
+			if tt.code == "" {
+				if err != nil {
+					t.Fatalf("expected %s to decode, got %v", tt.data, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected %s to fail with %q, got %#v", tt.data, tt.code, payload)
+			}
+			if err.Code != tt.code {
+				t.Fatalf("expected %q, got %q (%s)", tt.code, err.Code, err.Message)
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	if version, ok := Negotiate(""); !ok || version != Version {
+		t.Fatalf("expected the current version by default, got %d", version)
+	}
+	if version, ok := Negotiate("1"); !ok || version != 1 {
+		t.Fatalf("expected version 1 to be supported, got %d", version)
+	}
+	for _, requested := range []string{"0", "99", "v1"} {
+		if _, ok := Negotiate(requested); ok {
+			t.Fatalf("expected %q to be rejected", requested)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package protocol
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+type schemaBuilder struct {
+	defs map[string]any
+}
+
+func Schema() map[string]any {
+	builder := &schemaBuilder{defs: make(map[string]any)}
+
+	builder.defs["InboundMessage"] = map[string]any{
+		"oneOf": messageSchemas(builder, inbound, false),
+	}
+	builder.defs["OutboundMessage"] = map[string]any{
+		"oneOf": messageSchemas(builder, outbound, true),
+	}
+
+	return map[string]any{
+		"$schema":            schemaDialect,
+		"title":              "Playzio game WebSocket protocol",
+		"x-protocol-version": Version,
+		"oneOf": []any{
+			map[string]any{"$ref": "#/$defs/InboundMessage"},
+			map[string]any{"$ref": "#/$defs/OutboundMessage"},
+		},
+		"$defs": builder.defs,
+	}
+}
+
+func SchemaJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(Schema(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func messageSchemas[T any](builder *schemaBuilder, registry map[string]func() T, payloadRequired bool) []any {
+	messageTypes := make([]string, 0, len(registry))
+	for messageType := range registry {
+		messageTypes = append(messageTypes, messageType)
+	}
+	slices.Sort(messageTypes)
+
+	required := []string{"type"}
+	if payloadRequired {
+		required = append(required, "payload")
+	}
+
+	schemas := make([]any, 0, len(messageTypes))
+	for _, messageType := range messageTypes {
+		payloadType := reflect.TypeOf(registry[messageType]())
+		schemas = append(schemas, map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"type":    map[string]any{"const": messageType},
+				"payload": builder.typeSchema(payloadType),
+			},
+			"required":             required,
+			"additionalProperties": false,
+		})
+	}
+	return schemas
+}
+
+func (b *schemaBuilder) typeSchema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return b.typeSchema(t.Elem())
+	case reflect.Struct:
+		return b.structRef(t)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": []string{"array", "null"}, "items": b.typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": []string{"object", "null"}, "additionalProperties": b.typeSchema(t.Elem())}
+	default:
+		return map[string]any{}
+	}
+}
+
+func (b *schemaBuilder) structRef(t reflect.Type) map[string]any {
+	ref := map[string]any{"$ref": "#/$defs/" + t.Name()}
+	if _, defined := b.defs[t.Name()]; defined {
+		return ref
+	}
+	b.defs[t.Name()] = nil
+
+	properties := make(map[string]any)
+	required := make([]string, 0)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = b.typeSchema(field.Type)
+		if !slices.Contains(strings.Split(options, ","), "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	b.defs[t.Name()] = map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+	return ref
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/bootstrap/database"
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+type ChatRepository interface {
+	CreateMessage(c *gin.Context, message *model.ChatMessage) error
+	GetRoomMessages(c *gin.Context, roomID uint, before uint, limit int) ([]model.ChatMessage, error)
+}
+
+type chatRepository struct{}
+
+func NewChatRepository() ChatRepository {
+	return &chatRepository{}
+}
+
+func (r *chatRepository) CreateMessage(c *gin.Context, message *model.ChatMessage) error {
+	if err := database.Db.Create(message).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *chatRepository) GetRoomMessages(c *gin.Context, roomID uint, before uint, limit int) ([]model.ChatMessage, error) {
+	query := database.Db.Where("room_id = ?", roomID)
+	if before > 0 {
+		query = query.Where("id < ?", before)
+	}
+
+	var messages []model.ChatMessage
+	if err := query.Order("id DESC").Limit(limit).Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
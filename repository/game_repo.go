@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/bootstrap/database"
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GameRepository interface {
+	CreateGameWithRatings(ctx context.Context, game *model.Game, userIDs []uint, rate func(current map[uint]int) map[uint]int) error
+	GetGameByID(c *gin.Context, id uint) (model.Game, error)
+	GetGamesByParticipantID(c *gin.Context, participantID string, limit int) ([]model.Game, error)
+}
+
+type gameRepository struct{}
+
+func NewGameRepository() GameRepository {
+	return &gameRepository{}
+}
+
+func (r *gameRepository) CreateGameWithRatings(ctx context.Context, game *model.Game, userIDs []uint, rate func(current map[uint]int) map[uint]int) error {
+	return database.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current := make(map[uint]int, len(userIDs))
+		if len(userIDs) > 0 {
+			var users []model.User
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id IN ?", userIDs).
+				Order("id").
+				Find(&users).Error; err != nil {
+				return err
+			}
+			for _, user := range users {
+				current[user.ID] = user.Rating
+			}
+		}
+
+		ratings := rate(current)
+		if err := tx.Create(game).Error; err != nil {
+			return err
+		}
+		for userID, rating := range ratings {
+			if err := tx.Model(&model.User{}).
+				Where("id = ?", userID).
+				Update("rating", rating).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *gameRepository) GetGameByID(c *gin.Context, id uint) (model.Game, error) {
+	var game model.Game
+	if err := database.Db.Preload("Players").
+		Preload("Turns", func(db *gorm.DB) *gorm.DB {
+			return db.Order("turn_number ASC")
+		}).
+		First(&game, id).Error; err != nil {
+		return model.Game{}, err
+	}
+	return game, nil
+}
+
+func (r *gameRepository) GetGamesByParticipantID(c *gin.Context, participantID string, limit int) ([]model.Game, error) {
+	var games []model.Game
+	if err := database.Db.
+		Joins("JOIN game_players ON game_players.game_id = games.id AND game_players.deleted_at IS NULL").
+		Where("game_players.participant_id = ?", participantID).
+		Preload("Players").
+		Order("games.ended_at DESC").
+		Limit(limit).
+		Find(&games).Error; err != nil {
+		return nil, err
+	}
+	return games, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/lakshya1goel/Playzio/bootstrap/database"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newDryRunDb(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previous := database.Db
+	database.Db = db
+	t.Cleanup(func() { database.Db = previous })
+	return db
+}
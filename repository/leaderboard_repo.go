@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/bootstrap/database"
+	"github.com/lakshya1goel/Playzio/domain/dto"
+	"gorm.io/gorm"
+)
+
+type LeaderboardRepository interface {
+	GetLeaderboard(c *gin.Context, filter dto.LeaderboardFilter) ([]dto.LeaderboardEntry, error)
+}
+
+type leaderboardRepository struct{}
+
+func NewLeaderboardRepository() LeaderboardRepository {
+	return &leaderboardRepository{}
+}
+
+func (r *leaderboardRepository) GetLeaderboard(c *gin.Context, filter dto.LeaderboardFilter) ([]dto.LeaderboardEntry, error) {
+	var entries []dto.LeaderboardEntry
+	if err := leaderboardQuery(database.Db, filter).Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	return entries, nil
+}
+
+func leaderboardQuery(db *gorm.DB, filter dto.LeaderboardFilter) *gorm.DB {
+	query := db.Table("game_players").
+		Select(`game_players.participant_id,
+			MAX(game_players.user_id) AS user_id,
+			MAX(game_players.user_name) AS user_name,
+			SUM(CASE WHEN game_players.is_winner THEN 1 ELSE 0 END) AS wins,
+			COUNT(*) AS games_played,
+			SUM(game_players.final_points) AS total_points,
+			COALESCE(MAX(users.rating), 0) AS rating`).
+		Joins("JOIN games ON games.id = game_players.game_id AND games.deleted_at IS NULL").
+		Joins("LEFT JOIN users ON users.id = game_players.user_id").
+		Where("game_players.deleted_at IS NULL").
+		Where("game_players.is_bot = ?", false)
+
+	if filter.Since != nil {
+		query = query.Where("games.ended_at >= ?", *filter.Since)
+	}
+	if filter.RoomID != nil {
+		query = query.Where("games.room_id = ?", *filter.RoomID)
+	}
+	if filter.OnlyUsers {
+		query = query.Where("game_players.user_id IS NOT NULL")
+	}
+
+	order := "wins DESC, rating DESC, total_points DESC"
+	if filter.SortBy == "rating" {
+		order = "rating DESC, wins DESC, total_points DESC"
+	}
+	return query.Group("game_players.participant_id").
+		Order(order).
+		Limit(filter.Limit)
+}
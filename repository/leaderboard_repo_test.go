@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lakshya1goel/Playzio/domain/dto"
+	"gorm.io/gorm"
+)
+
+func TestLeaderboardQueryExcludesBots(t *testing.T) {
+	db := newDryRunDb(t)
+
+	roomID := uint(3)
+	tests := []struct {
+		name   string
+		filter dto.LeaderboardFilter
+		want   []string
+	}{
+		{name: "global", filter: dto.LeaderboardFilter{Limit: 10}, want: []string{"game_players.is_bot = false"}},
+		{name: "room", filter: dto.LeaderboardFilter{RoomID: &roomID, Limit: 10}, want: []string{"game_players.is_bot = false", "games.room_id = 3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				var entries []dto.LeaderboardEntry
+				return leaderboardQuery(tx, tt.filter).Scan(&entries)
+			})
+			for _, clause := range tt.want {
+				if !strings.Contains(sql, clause) {
+					t.Fatalf("expected %q in %s", clause, sql)
+				}
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package repository
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/lakshya1goel/Playzio/bootstrap/database"
+	"github.com/lakshya1goel/Playzio/bootstrap/util"
 	"github.com/lakshya1goel/Playzio/domain/model"
+	"gorm.io/gorm"
 )
 
 type RoomMemberRepository interface {
@@ -13,6 +15,9 @@ type RoomMemberRepository interface {
 	UpdateRoomMemberToCreator(c *gin.Context, roomID uint, member model.RoomMember) error
 	GetRoomMemberByGuestID(c *gin.Context, guestID string) (model.RoomMember, error)
 	DeleteRoomMemberByGuestID(c *gin.Context, roomID uint, guestID string) error
+	GetRoomMemberByParticipantID(c *gin.Context, roomID uint, participantID string) (model.RoomMember, error)
+	SetRoomMemberMuted(c *gin.Context, roomID uint, participantID string, muted bool) error
+	KickRoomMember(c *gin.Context, roomID uint, participantID string) error
 }
 
 type roomMemberRepository struct{}
@@ -23,7 +28,7 @@ func NewRoomMemberRepository() RoomMemberRepository {
 
 func (r *roomMemberRepository) GetRoomMemberByUserID(c *gin.Context, userID uint) (model.RoomMember, error) {
 	var member model.RoomMember
-	if err := database.Db.Where("user_id = ?", userID).Preload("User").First(&member).Error; err != nil {
+	if err := database.Db.Where("user_id = ? AND is_kicked = ?", userID, false).Preload("User").First(&member).Error; err != nil {
 		return model.RoomMember{}, err
 	}
 	return member, nil
@@ -38,7 +43,7 @@ func (r *roomMemberRepository) DeleteRoomMember(c *gin.Context, roomID uint, use
 
 func (r *roomMemberRepository) GetRoomMembersByRoomID(c *gin.Context, roomID uint) ([]model.RoomMember, error) {
 	var members []model.RoomMember
-	if err := database.Db.Where("room_id = ?", roomID).Preload("User").Find(&members).Error; err != nil {
+	if err := database.Db.Where("room_id = ? AND is_kicked = ?", roomID, false).Preload("User").Find(&members).Error; err != nil {
 		return []model.RoomMember{}, err
 	}
 	return members, nil
@@ -63,7 +68,7 @@ func (r *roomMemberRepository) UpdateRoomMemberToCreator(c *gin.Context, roomID
 
 func (r *roomMemberRepository) GetRoomMemberByGuestID(c *gin.Context, guestID string) (model.RoomMember, error) {
 	var member model.RoomMember
-	if err := database.Db.Where("guest_id = ?", guestID).Preload("User").First(&member).Error; err != nil {
+	if err := database.Db.Where("guest_id = ? AND is_kicked = ?", guestID, false).Preload("User").First(&member).Error; err != nil {
 		return model.RoomMember{}, err
 	}
 	return member, nil
@@ -75,3 +80,43 @@ func (r *roomMemberRepository) DeleteRoomMemberByGuestID(c *gin.Context, roomID
 	}
 	return nil
 }
+
+func (r *roomMemberRepository) GetRoomMemberByParticipantID(c *gin.Context, roomID uint, participantID string) (model.RoomMember, error) {
+	query, err := participantQuery(roomID, participantID)
+	if err != nil {
+		return model.RoomMember{}, err
+	}
+
+	var member model.RoomMember
+	if err := query.Preload("User").First(&member).Error; err != nil {
+		return model.RoomMember{}, err
+	}
+	return member, nil
+}
+
+func (r *roomMemberRepository) SetRoomMemberMuted(c *gin.Context, roomID uint, participantID string, muted bool) error {
+	query, err := participantQuery(roomID, participantID)
+	if err != nil {
+		return err
+	}
+	return query.Update("is_muted", muted).Error
+}
+
+func (r *roomMemberRepository) KickRoomMember(c *gin.Context, roomID uint, participantID string) error {
+	query, err := participantQuery(roomID, participantID)
+	if err != nil {
+		return err
+	}
+	return query.Updates(map[string]any{"is_kicked": true, "is_creator": false}).Error
+}
+
+func participantQuery(roomID uint, participantID string) (*gorm.DB, error) {
+	query := database.Db.Model(&model.RoomMember{}).Where("room_id = ?", roomID)
+	if userID, ok := util.ParseUserParticipantID(participantID); ok {
+		return query.Where("user_id = ?", userID), nil
+	}
+	if guestID, ok := util.ParseGuestParticipantID(participantID); ok {
+		return query.Where("guest_id = ?", guestID), nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
@@ -11,6 +11,7 @@ type RoomRepository interface {
 	GetRoomByID(c *gin.Context, id uint) (model.Room, error)
 	GetRoomByJoinCode(c *gin.Context, joinCode string) (model.Room, error)
 	UpdateRoom(c *gin.Context, room model.Room) error
+	UpdateRoomSettings(c *gin.Context, roomID uint, settings model.GameSettings) error
 	AddRoomMember(c *gin.Context, member *model.RoomMember) error
 	IsUserInRoom(c *gin.Context, roomID uint, userID uint) (bool, error)
 	IsGuestInRoom(c *gin.Context, roomID uint, guestID string) (bool, error)
@@ -56,6 +57,29 @@ func (r *roomRepository) UpdateRoom(c *gin.Context, room model.Room) error {
 	return nil
 }
 
+func (r *roomRepository) UpdateRoomSettings(c *gin.Context, roomID uint, settings model.GameSettings) error {
+	if err := database.Db.Model(&model.Room{}).
+		Where("id = ?", roomID).
+		Updates(map[string]any{
+			"setting_lives":                settings.Lives,
+			"setting_life_cap":             settings.LifeCap,
+			"setting_max_turn_time":        settings.MaxTurnTime,
+			"setting_min_turn_time":        settings.MinTurnTime,
+			"setting_turn_time_decay":      settings.TurnTimeDecay,
+			"setting_min_word_length":      settings.MinWordLength,
+			"setting_max_players":          settings.MaxPlayers,
+			"setting_min_players_to_start": settings.MinPlayersToStart,
+			"setting_scoring":              settings.Scoring,
+			"setting_mode":                 settings.Mode,
+			"setting_team_count":           settings.TeamCount,
+			"setting_race_winners":         settings.RaceWinners,
+			"setting_rotate_start":         settings.RotateStart,
+		}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 func (r *roomRepository) AddRoomMember(c *gin.Context, member *model.RoomMember) error {
 	if err := database.Db.Create(member).Error; err != nil {
 		return err
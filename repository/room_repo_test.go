@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+func TestCreateRoomKeepsZeroTurnTimeDecay(t *testing.T) {
+	newDryRunDb(t)
+
+	room := model.Room{Settings: model.DefaultGameSettings()}
+	room.Settings.TurnTimeDecay = 0
+
+	created, err := NewRoomRepository().CreateRoom(nil, room)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Settings.TurnTimeDecay != 0 {
+		t.Fatalf("expected a turn time decay of 0, got %d", created.Settings.TurnTimeDecay)
+	}
+}
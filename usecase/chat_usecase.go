@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/domain"
+	"github.com/lakshya1goel/Playzio/domain/dto"
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultChatHistoryLimit = 50
+	MaxChatHistoryLimit     = 100
+)
+
+type ChatUsecase interface {
+	SaveMessage(c *gin.Context, message *model.ChatMessage) *domain.HttpError
+	GetRecentMessages(c *gin.Context, roomID uint, limit int) ([]model.ChatMessage, *domain.HttpError)
+	GetRoomMessages(c *gin.Context, roomID uint, participantID string, before uint, limit int) (*dto.ChatHistoryResponse, *domain.HttpError)
+}
+
+type chatUsecase struct {
+	chatRepo       repository.ChatRepository
+	roomRepo       repository.RoomRepository
+	roomMemberRepo repository.RoomMemberRepository
+}
+
+func NewChatUsecase() ChatUsecase {
+	return &chatUsecase{
+		chatRepo:       repository.NewChatRepository(),
+		roomRepo:       repository.NewRoomRepository(),
+		roomMemberRepo: repository.NewRoomMemberRepository(),
+	}
+}
+
+func (cu *chatUsecase) SaveMessage(c *gin.Context, message *model.ChatMessage) *domain.HttpError {
+	if err := cu.chatRepo.CreateMessage(c, message); err != nil {
+		return &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to save chat message",
+		}
+	}
+	return nil
+}
+
+func (cu *chatUsecase) GetRecentMessages(c *gin.Context, roomID uint, limit int) ([]model.ChatMessage, *domain.HttpError) {
+	messages, err := cu.getMessages(c, roomID, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (cu *chatUsecase) GetRoomMessages(c *gin.Context, roomID uint, participantID string, before uint, limit int) (*dto.ChatHistoryResponse, *domain.HttpError) {
+	if _, err := cu.roomRepo.GetRoomByID(c, roomID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.HttpError{
+				StatusCode: http.StatusNotFound,
+				Message:    "Room not found",
+			}
+		}
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to retrieve room",
+		}
+	}
+
+	member, err := cu.roomMemberRepo.GetRoomMemberByParticipantID(c, roomID, participantID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to check room membership",
+		}
+	}
+	if err != nil || member.IsKicked {
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusForbidden,
+			Message:    "Only room members can read its messages",
+		}
+	}
+
+	if limit <= 0 {
+		limit = DefaultChatHistoryLimit
+	}
+	limit = min(limit, MaxChatHistoryLimit)
+
+	messages, httpErr := cu.getMessages(c, roomID, before, limit)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	response := &dto.ChatHistoryResponse{Messages: messages}
+	if len(messages) == limit {
+		cursor := messages[0].ID
+		response.NextCursor = &cursor
+	}
+	return response, nil
+}
+
+func (cu *chatUsecase) getMessages(c *gin.Context, roomID uint, before uint, limit int) ([]model.ChatMessage, *domain.HttpError) {
+	messages, err := cu.chatRepo.GetRoomMessages(c, roomID, before, limit)
+	if err != nil {
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to retrieve chat messages",
+		}
+	}
+
+	slices.Reverse(messages)
+	return messages, nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain"
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultGameHistoryLimit = 20
+	MaxGameHistoryLimit     = 100
+)
+
+type GameUsecase interface {
+	RecordGame(ctx context.Context, game *model.Game) (map[string]int, *domain.HttpError)
+	GetGame(c *gin.Context, id uint) (*model.Game, *domain.HttpError)
+	GetGameHistory(c *gin.Context, participantID string, limit int) ([]model.Game, *domain.HttpError)
+}
+
+type gameUsecase struct {
+	gameRepo repository.GameRepository
+}
+
+func NewGameUsecase() GameUsecase {
+	return &gameUsecase{
+		gameRepo: repository.NewGameRepository(),
+	}
+}
+
+func (gu *gameUsecase) RecordGame(ctx context.Context, game *model.Game) (map[string]int, *domain.HttpError) {
+	userIDs := make([]uint, 0, len(game.Players))
+	for _, player := range game.Players {
+		if player.UserID != nil {
+			userIDs = append(userIDs, *player.UserID)
+		}
+	}
+
+	err := gu.gameRepo.CreateGameWithRatings(ctx, game, userIDs, func(current map[uint]int) map[uint]int {
+		return applyRatingChanges(game, current)
+	})
+	if err != nil {
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to save game",
+		}
+	}
+
+	changes := make(map[string]int, len(game.Players))
+	for _, player := range game.Players {
+		if player.UserID != nil {
+			changes[player.ParticipantID] = player.RatingChange
+		}
+	}
+	return changes, nil
+}
+
+func applyRatingChanges(game *model.Game, currentRatings map[uint]int) map[uint]int {
+	rated := make([]int, 0, len(game.Players))
+	for i, player := range game.Players {
+		if player.UserID != nil {
+			rated = append(rated, i)
+		}
+	}
+	if len(rated) < 2 {
+		return nil
+	}
+
+	placements := gamePlacements(game.Players)
+	ratings := make([]int, len(rated))
+	ratedPlacements := make([]int, len(rated))
+	for i, index := range rated {
+		rating, ok := currentRatings[*game.Players[index].UserID]
+		if !ok || rating == 0 {
+			rating = util.DefaultRating
+		}
+		ratings[i] = rating
+		ratedPlacements[i] = placements[index]
+	}
+
+	changes := util.CalculateEloChanges(ratings, ratedPlacements)
+	newRatings := make(map[uint]int, len(rated))
+	for i, index := range rated {
+		game.Players[index].RatingChange = changes[i]
+		game.Players[index].Rating = ratings[i] + changes[i]
+		newRatings[*game.Players[index].UserID] = game.Players[index].Rating
+	}
+	return newRatings
+}
+
+func gamePlacements(players []model.GamePlayer) []int {
+	ranksAbove := func(a, b model.GamePlayer) bool {
+		if a.IsWinner != b.IsWinner {
+			return a.IsWinner
+		}
+		if a.FinalLives != b.FinalLives {
+			return a.FinalLives > b.FinalLives
+		}
+		return a.FinalPoints > b.FinalPoints
+	}
+
+	placements := make([]int, len(players))
+	for i, player := range players {
+		for _, other := range players {
+			if ranksAbove(other, player) {
+				placements[i]++
+			}
+		}
+	}
+	return placements
+}
+
+func (gu *gameUsecase) GetGame(c *gin.Context, id uint) (*model.Game, *domain.HttpError) {
+	game, err := gu.gameRepo.GetGameByID(c, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &domain.HttpError{
+				StatusCode: http.StatusNotFound,
+				Message:    "Game not found",
+			}
+		}
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to retrieve game",
+		}
+	}
+
+	return &game, nil
+}
+
+func (gu *gameUsecase) GetGameHistory(c *gin.Context, participantID string, limit int) ([]model.Game, *domain.HttpError) {
+	if participantID == "" {
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Participant ID is required",
+		}
+	}
+
+	if limit <= 0 {
+		limit = DefaultGameHistoryLimit
+	}
+	limit = min(limit, MaxGameHistoryLimit)
+
+	games, err := gu.gameRepo.GetGamesByParticipantID(c, participantID, limit)
+	if err != nil {
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to retrieve game history",
+		}
+	}
+
+	return games, nil
+}
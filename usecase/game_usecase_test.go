@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+func TestGamePlacements(t *testing.T) {
+	players := []model.GamePlayer{
+		{ParticipantID: "user:1", FinalLives: 0, FinalPoints: 50},
+		{ParticipantID: "user:2", FinalLives: 2, FinalPoints: 10, IsWinner: true},
+		{ParticipantID: "guest:a", FinalLives: 0, FinalPoints: 20},
+		{ParticipantID: "guest:b", FinalLives: 1, FinalPoints: 0},
+		{ParticipantID: "guest:c", FinalLives: 0, FinalPoints: 50},
+	}
+
+	want := []int{2, 0, 4, 1, 2}
+	if got := gamePlacements(players); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestApplyRatingChanges(t *testing.T) {
+	winner, loser := uint(1), uint(2)
+	game := &model.Game{
+		Players: []model.GamePlayer{
+			{ParticipantID: "user:1", UserID: &winner, IsWinner: true},
+			{ParticipantID: "guest:a", FinalLives: 1},
+			{ParticipantID: "user:2", UserID: &loser},
+		},
+	}
+
+	ratings := applyRatingChanges(game, map[uint]int{winner: 1200})
+	if ratings[winner] != 1216 || ratings[loser] != util.DefaultRating-16 {
+		t.Fatalf("expected 1216 and %d, got %v", util.DefaultRating-16, ratings)
+	}
+	if game.Players[0].RatingChange != 16 || game.Players[2].RatingChange != -16 {
+		t.Fatalf("expected rating changes of 16 and -16, got %+v", game.Players)
+	}
+	if game.Players[1].RatingChange != 0 || len(ratings) != 2 {
+		t.Fatalf("expected guests to stay unrated, got %+v", game.Players[1])
+	}
+
+	solo := &model.Game{Players: []model.GamePlayer{{UserID: &winner, IsWinner: true}}}
+	if ratings := applyRatingChanges(solo, nil); ratings != nil {
+		t.Fatalf("expected no rating changes with a single rated player, got %v", ratings)
+	}
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/domain"
+	"github.com/lakshya1goel/Playzio/domain/dto"
+	"github.com/lakshya1goel/Playzio/repository"
+)
+
+const (
+	LeaderboardAllTime      = "all"
+	LeaderboardWeekly       = "weekly"
+	LeaderboardSortByWins   = "wins"
+	LeaderboardSortByRating = "rating"
+	DefaultLeaderboardLimit = 20
+	MaxLeaderboardLimit     = 100
+)
+
+type LeaderboardUsecase interface {
+	GetGlobalLeaderboard(c *gin.Context, period string, sortBy string, limit int) ([]dto.LeaderboardEntry, *domain.HttpError)
+	GetRoomLeaderboard(c *gin.Context, roomID uint, sortBy string, limit int) ([]dto.LeaderboardEntry, *domain.HttpError)
+}
+
+type leaderboardUsecase struct {
+	leaderboardRepo repository.LeaderboardRepository
+}
+
+func NewLeaderboardUsecase() LeaderboardUsecase {
+	return &leaderboardUsecase{
+		leaderboardRepo: repository.NewLeaderboardRepository(),
+	}
+}
+
+func (lu *leaderboardUsecase) GetGlobalLeaderboard(c *gin.Context, period string, sortBy string, limit int) ([]dto.LeaderboardEntry, *domain.HttpError) {
+	filter, httpErr := lu.buildFilter(sortBy, limit)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	filter.OnlyUsers = true
+
+	switch period {
+	case LeaderboardAllTime:
+	case LeaderboardWeekly:
+		since := time.Now().AddDate(0, 0, -7)
+		filter.Since = &since
+	default:
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Invalid leaderboard period",
+		}
+	}
+
+	return lu.getLeaderboard(c, filter)
+}
+
+func (lu *leaderboardUsecase) GetRoomLeaderboard(c *gin.Context, roomID uint, sortBy string, limit int) ([]dto.LeaderboardEntry, *domain.HttpError) {
+	filter, httpErr := lu.buildFilter(sortBy, limit)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	filter.RoomID = &roomID
+
+	return lu.getLeaderboard(c, filter)
+}
+
+func (lu *leaderboardUsecase) buildFilter(sortBy string, limit int) (dto.LeaderboardFilter, *domain.HttpError) {
+	if sortBy == "" {
+		sortBy = LeaderboardSortByWins
+	}
+	if sortBy != LeaderboardSortByWins && sortBy != LeaderboardSortByRating {
+		return dto.LeaderboardFilter{}, &domain.HttpError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Invalid leaderboard sort",
+		}
+	}
+
+	if limit <= 0 {
+		limit = DefaultLeaderboardLimit
+	}
+
+	return dto.LeaderboardFilter{
+		SortBy: sortBy,
+		Limit:  min(limit, MaxLeaderboardLimit),
+	}, nil
+}
+
+func (lu *leaderboardUsecase) getLeaderboard(c *gin.Context, filter dto.LeaderboardFilter) ([]dto.LeaderboardEntry, *domain.HttpError) {
+	entries, err := lu.leaderboardRepo.GetLeaderboard(c, filter)
+	if err != nil {
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to retrieve leaderboard",
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain"
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/repository"
+	"gorm.io/gorm"
+)
+
+type ModerationUsecase interface {
+	GetMember(c *gin.Context, roomID uint, participantID string) (*model.RoomMember, *domain.HttpError)
+	MuteMember(c *gin.Context, roomID uint, hostID string, targetID string, muted bool) *domain.HttpError
+	KickMember(c *gin.Context, roomID uint, hostID string, targetID string) *domain.HttpError
+}
+
+type moderationUsecase struct {
+	roomRepo       repository.RoomRepository
+	roomMemberRepo repository.RoomMemberRepository
+}
+
+func NewModerationUsecase() ModerationUsecase {
+	return &moderationUsecase{
+		roomRepo:       repository.NewRoomRepository(),
+		roomMemberRepo: repository.NewRoomMemberRepository(),
+	}
+}
+
+func (mu *moderationUsecase) GetMember(c *gin.Context, roomID uint, participantID string) (*model.RoomMember, *domain.HttpError) {
+	member, err := mu.roomMemberRepo.GetRoomMemberByParticipantID(c, roomID, participantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to retrieve room member",
+		}
+	}
+	return &member, nil
+}
+
+func (mu *moderationUsecase) MuteMember(c *gin.Context, roomID uint, hostID string, targetID string, muted bool) *domain.HttpError {
+	if err := mu.authorize(c, roomID, hostID, targetID); err != nil {
+		return err
+	}
+
+	if err := mu.roomMemberRepo.SetRoomMemberMuted(c, roomID, targetID, muted); err != nil {
+		return &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to update member",
+		}
+	}
+	return nil
+}
+
+func (mu *moderationUsecase) KickMember(c *gin.Context, roomID uint, hostID string, targetID string) *domain.HttpError {
+	if err := mu.authorize(c, roomID, hostID, targetID); err != nil {
+		return err
+	}
+
+	if err := mu.roomMemberRepo.KickRoomMember(c, roomID, targetID); err != nil {
+		return &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to remove member",
+		}
+	}
+	return nil
+}
+
+func (mu *moderationUsecase) authorize(c *gin.Context, roomID uint, hostID string, targetID string) *domain.HttpError {
+	room, err := mu.roomRepo.GetRoomByID(c, roomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &domain.HttpError{
+				StatusCode: http.StatusNotFound,
+				Message:    "Room not found",
+			}
+		}
+		return &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to retrieve room",
+		}
+	}
+
+	if util.MemberParticipantID(room.CreatedBy, room.CreatorGuestID) != hostID {
+		return &domain.HttpError{
+			StatusCode: http.StatusForbidden,
+			Message:    "Only the host can moderate this room",
+		}
+	}
+	if targetID == hostID {
+		return &domain.HttpError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "The host cannot moderate themselves",
+		}
+	}
+
+	member, httpErr := mu.GetMember(c, roomID, targetID)
+	if httpErr != nil {
+		return httpErr
+	}
+	if member == nil || member.IsKicked {
+		return &domain.HttpError{
+			StatusCode: http.StatusNotFound,
+			Message:    "Member not found",
+		}
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package usecase
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lakshya1goel/Playzio/bootstrap/database"
+	"github.com/lakshya1goel/Playzio/bootstrap/dictionary"
 	"github.com/lakshya1goel/Playzio/bootstrap/util"
 	"github.com/lakshya1goel/Playzio/domain"
 	"github.com/lakshya1goel/Playzio/domain/dto"
@@ -13,7 +15,8 @@ import (
 )
 
 type RoomUsecase interface {
-	CreateRoom(c *gin.Context, room model.Room) (*model.Room, *domain.HttpError)
+	CreateRoom(c *gin.Context, room model.Room, settings *dto.GameSettingsRequest) (*model.Room, *domain.HttpError)
+	UpdateRoomSettings(c *gin.Context, roomID uint, settings dto.GameSettingsRequest) (*model.Room, *domain.HttpError)
 	JoinRoom(c *gin.Context, joinCode string) (*model.Room, *domain.HttpError)
 	GetAllPublicRooms(c *gin.Context) ([]model.Room, *domain.HttpError)
 	LeaveRoom(c *gin.Context) *domain.HttpError
@@ -33,7 +36,22 @@ func NewRoomUsecase() RoomUsecase {
 	}
 }
 
-func (ru *roomUsecase) CreateRoom(c *gin.Context, room model.Room) (*model.Room, *domain.HttpError) {
+func (ru *roomUsecase) CreateRoom(c *gin.Context, room model.Room, settings *dto.GameSettingsRequest) (*model.Room, *domain.HttpError) {
+	room.Settings = ru.mergeGameSettings(model.DefaultGameSettings(), settings)
+	if err := ru.validateGameSettings(room.Settings); err != nil {
+		return nil, err
+	}
+
+	if room.Language == "" {
+		room.Language = dictionary.Dictionaries.DefaultLanguage()
+	}
+	if !dictionary.Dictionaries.Has(room.Language) {
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Unsupported room language",
+		}
+	}
+
 	joinCode, err := util.GenerateRandomCode(6)
 	if err != nil {
 		return nil, &domain.HttpError{
@@ -79,6 +97,48 @@ func (ru *roomUsecase) CreateRoom(c *gin.Context, room model.Room) (*model.Room,
 	return &createdRoom, nil
 }
 
+func (ru *roomUsecase) UpdateRoomSettings(c *gin.Context, roomID uint, settings dto.GameSettingsRequest) (*model.Room, *domain.HttpError) {
+	room, err := ru.roomRepo.GetRoomByID(c, roomID)
+	if err != nil {
+		if err.Error() == "record not found" {
+			return nil, &domain.HttpError{
+				StatusCode: http.StatusNotFound,
+				Message:    "Room not found",
+			}
+		}
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to retrieve room",
+		}
+	}
+
+	userInfo, userErr := ru.extractUserInfo(c)
+	if userErr != nil {
+		return nil, userErr
+	}
+
+	if !ru.isRoomCreator(userInfo, room) {
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusForbidden,
+			Message:    "Only the room creator can change the game settings",
+		}
+	}
+
+	room.Settings = ru.mergeGameSettings(room.Settings, &settings)
+	if err := ru.validateGameSettings(room.Settings); err != nil {
+		return nil, err
+	}
+
+	if err := ru.roomRepo.UpdateRoomSettings(c, room.ID, room.Settings); err != nil {
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "Failed to update game settings",
+		}
+	}
+
+	return &room, nil
+}
+
 func (ru *roomUsecase) JoinRoom(c *gin.Context, joinCode string) (*model.Room, *domain.HttpError) {
 	room, err := ru.roomRepo.GetRoomByJoinCode(c, joinCode)
 	if err != nil {
@@ -102,6 +162,14 @@ func (ru *roomUsecase) JoinRoom(c *gin.Context, joinCode string) (*model.Room, *
 		}
 	}
 
+	participantID := util.MemberParticipantID(userInfo.UserID, userInfo.GuestID)
+	if member, err := ru.roomMemberRepo.GetRoomMemberByParticipantID(c, room.ID, participantID); err == nil && member.IsKicked {
+		return nil, &domain.HttpError{
+			StatusCode: http.StatusForbidden,
+			Message:    "You have been removed from this room",
+		}
+	}
+
 	exists, existsErr := ru.isUserInRoom(c, userInfo, room.ID)
 	if existsErr != nil {
 		return nil, &domain.HttpError{
@@ -228,6 +296,96 @@ func (ru *roomUsecase) extractUserInfo(c *gin.Context) (*dto.User, *domain.HttpE
 	return userInfo, nil
 }
 
+func (ru *roomUsecase) isRoomCreator(userInfo *dto.User, room model.Room) bool {
+	if userInfo.Type == "google" {
+		return room.CreatedBy != nil && *room.CreatedBy == *userInfo.UserID
+	}
+	return room.CreatorGuestID != nil && *room.CreatorGuestID == *userInfo.GuestID
+}
+
+func (ru *roomUsecase) mergeGameSettings(settings model.GameSettings, request *dto.GameSettingsRequest) model.GameSettings {
+	if request == nil {
+		return settings
+	}
+	if request.Lives != nil {
+		settings.Lives = *request.Lives
+	}
+	if request.LifeCap != nil {
+		settings.LifeCap = *request.LifeCap
+	}
+	if request.MaxTurnTime != nil {
+		settings.MaxTurnTime = *request.MaxTurnTime
+	}
+	if request.MinTurnTime != nil {
+		settings.MinTurnTime = *request.MinTurnTime
+	}
+	if request.TurnTimeDecay != nil {
+		settings.TurnTimeDecay = *request.TurnTimeDecay
+	}
+	if request.MinWordLength != nil {
+		settings.MinWordLength = *request.MinWordLength
+	}
+	if request.MaxPlayers != nil {
+		settings.MaxPlayers = *request.MaxPlayers
+	}
+	if request.MinPlayersToStart != nil {
+		settings.MinPlayersToStart = *request.MinPlayersToStart
+	}
+	if request.Scoring != nil {
+		settings.Scoring = *request.Scoring
+	}
+	if request.Mode != nil {
+		settings.Mode = *request.Mode
+	}
+	if request.TeamCount != nil {
+		settings.TeamCount = *request.TeamCount
+	}
+	if request.RaceWinners != nil {
+		settings.RaceWinners = *request.RaceWinners
+	}
+	if request.RotateStart != nil {
+		settings.RotateStart = *request.RotateStart
+	}
+	return settings
+}
+
+func (ru *roomUsecase) validateGameSettings(settings model.GameSettings) *domain.HttpError {
+	var message string
+	switch {
+	case settings.Lives < model.MinLives || settings.Lives > model.MaxLives:
+		message = fmt.Sprintf("Lives must be between %d and %d", model.MinLives, model.MaxLives)
+	case settings.LifeCap < settings.Lives || settings.LifeCap > model.MaxLives:
+		message = fmt.Sprintf("Life cap must be between the starting lives and %d", model.MaxLives)
+	case settings.MaxTurnTime < model.MinTurnTime || settings.MaxTurnTime > model.MaxTurnTime:
+		message = fmt.Sprintf("Max turn time must be between %d and %d seconds", model.MinTurnTime, model.MaxTurnTime)
+	case settings.MinTurnTime < model.MinTurnTime || settings.MinTurnTime > settings.MaxTurnTime:
+		message = fmt.Sprintf("Min turn time must be between %d seconds and the max turn time", model.MinTurnTime)
+	case settings.TurnTimeDecay < 0 || settings.TurnTimeDecay > model.MaxTurnTimeDecay:
+		message = fmt.Sprintf("Turn time decay must be between 0 and %d seconds", model.MaxTurnTimeDecay)
+	case settings.MinWordLength < 1 || settings.MinWordLength > model.MaxMinWordLength:
+		message = fmt.Sprintf("Min word length must be between 1 and %d", model.MaxMinWordLength)
+	case settings.MaxPlayers < model.MinPlayers || settings.MaxPlayers > model.MaxPlayers:
+		message = fmt.Sprintf("Max players must be between %d and %d", model.MinPlayers, model.MaxPlayers)
+	case settings.MinPlayersToStart < model.MinPlayers || settings.MinPlayersToStart > settings.MaxPlayers:
+		message = fmt.Sprintf("Min players to start must be between %d and the max players", model.MinPlayers)
+	case settings.Scoring != model.ScoringFlat && settings.Scoring != model.ScoringWeighted:
+		message = fmt.Sprintf("Scoring must be %q or %q", model.ScoringFlat, model.ScoringWeighted)
+	case settings.Mode != model.ModeFreeForAll && settings.Mode != model.ModeTeams && settings.Mode != model.ModeRace:
+		message = fmt.Sprintf("Mode must be %q, %q or %q", model.ModeFreeForAll, model.ModeTeams, model.ModeRace)
+	case settings.TeamCount < model.MinTeams || settings.TeamCount > model.MaxTeams:
+		message = fmt.Sprintf("Team count must be between %d and %d", model.MinTeams, model.MaxTeams)
+	case settings.RaceWinners < 1 || settings.RaceWinners > settings.MaxPlayers:
+		message = "Race winners must be between 1 and the max players"
+	default:
+		return nil
+	}
+
+	return &domain.HttpError{
+		StatusCode: http.StatusBadRequest,
+		Message:    message,
+	}
+}
+
 func (ru *roomUsecase) createRoomMember(userInfo *dto.User, roomID uint, isCreator bool) model.RoomMember {
 	member := model.RoomMember{
 		RoomID:    roomID,
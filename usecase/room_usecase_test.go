@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+func TestValidateGameSettings(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(*model.GameSettings)
+		valid  bool
+	}{
+		{name: "defaults", update: func(s *model.GameSettings) {}, valid: true},
+		{name: "min players to start at max players", update: func(s *model.GameSettings) { s.MinPlayersToStart = s.MaxPlayers }, valid: true},
+		{name: "min players to start below two", update: func(s *model.GameSettings) { s.MinPlayersToStart = 1 }},
+		{name: "min players to start above max players", update: func(s *model.GameSettings) {
+			s.MaxPlayers = 4
+			s.MinPlayersToStart = 5
+		}},
+		{name: "race winners above max players", update: func(s *model.GameSettings) { s.RaceWinners = s.MaxPlayers + 1 }},
+	}
+
+	ru := &roomUsecase{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := model.DefaultGameSettings()
+			tt.update(&settings)
+			err := ru.validateGameSettings(settings)
+			if tt.valid && err != nil {
+				t.Fatalf("expected valid settings, got %q", err.Message)
+			}
+			if !tt.valid && err == nil {
+				t.Fatal("expected settings to be rejected")
+			}
+		})
+	}
+}
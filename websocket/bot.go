@@ -0,0 +1,163 @@
+package websocket
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
+)
+
+type BotDifficulty struct {
+	AnswerProbability float64
+	MinDelay          time.Duration
+	MaxDelay          time.Duration
+	VocabularySize    int
+}
+
+type Bot struct {
+	Client     *GameClient
+	Difficulty BotDifficulty
+	vocabulary []string
+	used       map[string]bool
+	rng        *rand.Rand
+	stop       chan struct{}
+	stopOnce   sync.Once
+}
+
+func NewBot(pool *GamePool, roomID uint, name string, difficulty BotDifficulty, words []string, minWordLength int) *Bot {
+	bot := &Bot{
+		Client: &GameClient{
+			BaseClient: BaseClient{
+				UserId:   util.BotParticipantID(uuid.NewString()),
+				UserName: name,
+				RoomID:   roomID,
+				Outbox:   make(chan any, BotOutboxSize),
+			},
+			Pool:  pool,
+			IsBot: true,
+		},
+		Difficulty: difficulty,
+		used:       make(map[string]bool),
+		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		stop:       make(chan struct{}),
+	}
+	bot.vocabulary = bot.buildVocabulary(words, minWordLength)
+	return bot
+}
+
+func (b *Bot) buildVocabulary(words []string, minWordLength int) []string {
+	vocabulary := make([]string, 0, len(words))
+	for _, index := range b.rng.Perm(len(words)) {
+		if b.Difficulty.VocabularySize > 0 && len(vocabulary) >= b.Difficulty.VocabularySize {
+			break
+		}
+		if len([]rune(words[index])) >= minWordLength {
+			vocabulary = append(vocabulary, words[index])
+		}
+	}
+	return vocabulary
+}
+
+func (b *Bot) Run() {
+	for {
+		select {
+		case <-b.stop:
+			return
+		case raw := <-b.Client.Outbox:
+			msg, ok := raw.(model.GameMessage)
+			if !ok {
+				continue
+			}
+			b.handleMessage(msg)
+		}
+	}
+}
+
+func (b *Bot) Stop() {
+	b.stopOnce.Do(func() {
+		close(b.stop)
+	})
+}
+
+func (b *Bot) handleMessage(msg model.GameMessage) {
+	switch event := msg.Payload.(type) {
+	case protocol.StartGameEvent:
+		b.used = make(map[string]bool)
+	case protocol.AnswerEvent:
+		if event.Correct {
+			b.used[event.Answer] = true
+		}
+	case protocol.NextTurnEvent:
+		if event.UserID != "" && event.UserID != b.Client.UserId {
+			return
+		}
+		b.takeTurn(event.CharSet)
+	}
+}
+
+func (b *Bot) takeTurn(charSet string) {
+	if b.rng.Float64() >= b.Difficulty.AnswerProbability {
+		return
+	}
+
+	word := b.findWord(charSet)
+	if word == "" {
+		return
+	}
+
+	delay := b.Difficulty.MinDelay
+	if spread := b.Difficulty.MaxDelay - b.Difficulty.MinDelay; spread > 0 {
+		delay += time.Duration(b.rng.Int63n(int64(spread)))
+	}
+
+	go b.answer(word, delay)
+}
+
+func (b *Bot) findWord(charSet string) string {
+	if len(b.vocabulary) == 0 {
+		return ""
+	}
+
+	start := b.rng.Intn(len(b.vocabulary))
+	for offset := range b.vocabulary {
+		word := b.vocabulary[(start+offset)%len(b.vocabulary)]
+		if !b.used[word] && util.ContainsSubstring(word, charSet) {
+			return word
+		}
+	}
+	return ""
+}
+
+func (b *Bot) answer(word string, delay time.Duration) {
+	if !b.wait(delay / 2) {
+		return
+	}
+	letters := []rune(word)
+	b.Client.Pool.HandleMessage(b.Client, &protocol.TypingRequest{Text: string(letters[:len(letters)/2])})
+
+	if !b.wait(delay - delay/2) {
+		return
+	}
+	b.Client.Pool.HandleMessage(b.Client, &protocol.AnswerRequest{Answer: word})
+}
+
+func (b *Bot) wait(delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-b.stop:
+		return false
+	}
+}
+
+func botName(difficulty string, number int) string {
+	return fmt.Sprintf("Bot %d (%s)", number, difficulty)
+}
@@ -0,0 +1,46 @@
+package websocket
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+func TestHostAddsBotThatAnswers(t *testing.T) {
+	pool, server := newTestPool(t)
+	pool.botDifficulties = map[string]BotDifficulty{
+		"instant": {AnswerProbability: 1, MinDelay: 10 * time.Millisecond, MaxDelay: 20 * time.Millisecond},
+	}
+
+	host := dialTestClient(t, server, "user:1")
+	host.send(t, model.Join, map[string]any{"room_id": 5})
+	host.expect(t, model.UserJoined, fromUser("user:1"))
+
+	host.send(t, model.AddBot, map[string]any{"difficulty": "impossible"})
+	if code := host.expect(t, model.Error, nil).Payload["code"]; code != model.ErrorInvalidDifficulty {
+		t.Fatalf("expected %q, got %v", model.ErrorInvalidDifficulty, code)
+	}
+
+	host.send(t, model.AddBot, map[string]any{"difficulty": "instant"})
+	joined := host.expect(t, model.UserJoined, func(msg testMessage) bool {
+		return strings.HasPrefix(payloadString(msg, "user_id"), "bot:")
+	})
+	botID := payloadString(joined, "user_id")
+	if hostID := payloadString(joined, "host_id"); hostID != "user:1" {
+		t.Fatalf("expected user:1 to stay host, got %q", hostID)
+	}
+
+	host.send(t, model.StartGame, map[string]any{"duration": 0})
+	turn := host.expect(t, model.NextTurn, nil)
+	if payloadString(turn, "user_id") == host.userID {
+		host.send(t, model.Answer, map[string]any{"answer": validWord(t, pool, payloadString(turn, "char_set"))})
+	}
+
+	host.expect(t, model.Typing, fromUser(botID))
+	answer := host.expect(t, model.Answer, fromUser(botID))
+	if answer.Payload["correct"] != true {
+		t.Fatalf("expected the bot to answer correctly, got %v", answer.Payload)
+	}
+}
@@ -0,0 +1,148 @@
+package websocket
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lakshya1goel/Playzio/bootstrap/redis"
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+type MessageBus interface {
+	PublishToRoom(roomID uint, message model.ChatMessage) error
+	SubscribeToRoom(roomID uint, handler func(model.ChatMessage)) error
+	UnsubscribeFromRoom(roomID uint) error
+}
+
+type RemoteMessageBus interface {
+	MessageBus
+	Ping() error
+	SubscriptionStats() redis.SubscriptionStats
+}
+
+type localMessageBus struct {
+	mu       sync.RWMutex
+	handlers map[uint]func(model.ChatMessage)
+}
+
+func NewLocalMessageBus() MessageBus {
+	return &localMessageBus{
+		handlers: make(map[uint]func(model.ChatMessage)),
+	}
+}
+
+func (b *localMessageBus) PublishToRoom(roomID uint, message model.ChatMessage) error {
+	b.mu.RLock()
+	handler := b.handlers[roomID]
+	b.mu.RUnlock()
+
+	if handler != nil {
+		handler(message)
+	}
+	return nil
+}
+
+func (b *localMessageBus) SubscribeToRoom(roomID uint, handler func(model.ChatMessage)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[roomID] = handler
+	return nil
+}
+
+func (b *localMessageBus) UnsubscribeFromRoom(roomID uint) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.handlers, roomID)
+	return nil
+}
+
+type fallbackMessageBus struct {
+	remote  RemoteMessageBus
+	local   MessageBus
+	mu      sync.RWMutex
+	healthy bool
+}
+
+func NewFallbackMessageBus(remote RemoteMessageBus) MessageBus {
+	if remote == nil {
+		return NewLocalMessageBus()
+	}
+
+	bus := newFallbackMessageBus(remote)
+	go bus.monitor()
+	return bus
+}
+
+func newFallbackMessageBus(remote RemoteMessageBus) *fallbackMessageBus {
+	return &fallbackMessageBus{
+		remote:  remote,
+		local:   NewLocalMessageBus(),
+		healthy: remoteHealthy(remote),
+	}
+}
+
+func remoteHealthy(remote RemoteMessageBus) bool {
+	return remote.Ping() == nil && remote.SubscriptionStats().Connected
+}
+
+func (b *fallbackMessageBus) PublishToRoom(roomID uint, message model.ChatMessage) error {
+	if b.Healthy() {
+		err := b.remote.PublishToRoom(roomID, message)
+		if err == nil {
+			return nil
+		}
+		b.setHealthy(false)
+	}
+	return b.local.PublishToRoom(roomID, message)
+}
+
+func (b *fallbackMessageBus) SubscribeToRoom(roomID uint, handler func(model.ChatMessage)) error {
+	if err := b.remote.SubscribeToRoom(roomID, handler); err != nil {
+		fmt.Println("Remote subscription pending until Redis recovers: ", err)
+	}
+	return b.local.SubscribeToRoom(roomID, handler)
+}
+
+func (b *fallbackMessageBus) UnsubscribeFromRoom(roomID uint) error {
+	if err := b.remote.UnsubscribeFromRoom(roomID); err != nil {
+		fmt.Println("Error unsubscribing from remote bus: ", err)
+	}
+	return b.local.UnsubscribeFromRoom(roomID)
+}
+
+func (b *fallbackMessageBus) Healthy() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.healthy
+}
+
+func (b *fallbackMessageBus) monitor() {
+	ticker := time.NewTicker(BusHealthCheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		b.checkHealth()
+	}
+}
+
+func (b *fallbackMessageBus) checkHealth() {
+	b.setHealthy(remoteHealthy(b.remote))
+}
+
+func (b *fallbackMessageBus) setHealthy(healthy bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.healthy == healthy {
+		return
+	}
+	b.healthy = healthy
+	if healthy {
+		fmt.Println("Redis recovered, chat messages are published through Redis again")
+	} else {
+		fmt.Println("Redis unavailable, chat messages fall back to in-process delivery")
+	}
+}
@@ -0,0 +1,171 @@
+package websocket
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lakshya1goel/Playzio/bootstrap/redis"
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+type fakeRemoteBus struct {
+	mu           sync.Mutex
+	down         bool
+	disconnected bool
+	published    int
+	handlers     map[uint]func(model.ChatMessage)
+}
+
+func (b *fakeRemoteBus) PublishToRoom(roomID uint, message model.ChatMessage) error {
+	b.mu.Lock()
+	if b.down {
+		b.mu.Unlock()
+		return errors.New("connection refused")
+	}
+	b.published++
+	handler := b.handlers[roomID]
+	b.mu.Unlock()
+
+	if handler != nil {
+		handler(message)
+	}
+	return nil
+}
+
+func (b *fakeRemoteBus) SubscribeToRoom(roomID uint, handler func(model.ChatMessage)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[roomID] = handler
+	return nil
+}
+
+func (b *fakeRemoteBus) UnsubscribeFromRoom(roomID uint) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.handlers, roomID)
+	return nil
+}
+
+func (b *fakeRemoteBus) Ping() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.down {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (b *fakeRemoteBus) SubscriptionStats() redis.SubscriptionStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return redis.SubscriptionStats{Channels: len(b.handlers), Connected: !b.disconnected}
+}
+
+func (b *fakeRemoteBus) setDisconnected(disconnected bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.disconnected = disconnected
+}
+
+func (b *fakeRemoteBus) setDown(down bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.down = down
+}
+
+func (b *fakeRemoteBus) publishCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.published
+}
+
+func (b *fakeRemoteBus) subscribed(roomID uint) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.handlers[roomID] != nil
+}
+
+func expectChatMessage(t *testing.T, outbox chan any, body string) {
+	t.Helper()
+	select {
+	case msg := <-outbox:
+		if chat, ok := msg.(model.ChatMessage); !ok || chat.Body != body {
+			t.Fatalf("expected chat message %q, got %#v", body, msg)
+		}
+	case <-time.After(testMessageTimeout):
+		t.Fatalf("timed out waiting for chat message %q", body)
+	}
+}
+
+func TestChatFallsBackToLocalDeliveryWhileRedisIsDown(t *testing.T) {
+	remote := &fakeRemoteBus{handlers: make(map[uint]func(model.ChatMessage))}
+	bus := newFallbackMessageBus(remote)
+	pool := NewChatPool(bus, nil, nil, nil)
+	go pool.Start()
+
+	handler := NewChatHandler()
+	client := &ChatClient{
+		BaseClient: BaseClient{UserId: "user:1", Outbox: make(chan any, 8)},
+		Pool:       pool,
+	}
+	handler.JoinRoom(client, 3)
+	deadline := time.Now().Add(testMessageTimeout)
+	for !remote.subscribed(3) {
+		if time.Now().After(deadline) {
+			t.Fatal("chat pool never subscribed to the room")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	handler.BroadcastMessage(client, model.ChatMessage{Type: model.ChatContent, Body: "via redis"})
+	expectChatMessage(t, client.Outbox, "via redis")
+
+	remote.setDown(true)
+	handler.BroadcastMessage(client, model.ChatMessage{Type: model.ChatContent, Body: "via fallback"})
+	expectChatMessage(t, client.Outbox, "via fallback")
+	if bus.Healthy() {
+		t.Fatal("expected the bus to fall back after a failed publish")
+	}
+
+	remote.setDown(false)
+	bus.checkHealth()
+	handler.BroadcastMessage(client, model.ChatMessage{Type: model.ChatContent, Body: "promoted"})
+	expectChatMessage(t, client.Outbox, "promoted")
+	if count := remote.publishCount(); count != 2 {
+		t.Fatalf("expected 2 messages published through redis, got %d", count)
+	}
+}
+
+func TestChatFallsBackWhileRedisSubscriptionIsDisconnected(t *testing.T) {
+	remote := &fakeRemoteBus{handlers: make(map[uint]func(model.ChatMessage))}
+	bus := newFallbackMessageBus(remote)
+	if !bus.Healthy() {
+		t.Fatal("expected a connected remote bus to start healthy")
+	}
+
+	remote.setDisconnected(true)
+	bus.checkHealth()
+	if bus.Healthy() {
+		t.Fatal("expected the bus to fall back while the subscription is disconnected")
+	}
+
+	received := make(chan string, 1)
+	bus.SubscribeToRoom(4, func(message model.ChatMessage) {
+		received <- message.Body
+	})
+	bus.PublishToRoom(4, model.ChatMessage{Body: "local"})
+	if body := <-received; body != "local" {
+		t.Fatalf("expected local delivery, got %q", body)
+	}
+	if count := remote.publishCount(); count != 0 {
+		t.Fatalf("expected nothing published through redis, got %d", count)
+	}
+
+	remote.setDisconnected(false)
+	bus.checkHealth()
+	if !bus.Healthy() {
+		t.Fatal("expected the bus to recover once the subscription reconnects")
+	}
+}
@@ -1,16 +1,20 @@
 package websocket
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
 )
 
 type ChatHandler interface {
 	JoinRoom(c *ChatClient, roomID uint)
 	LeaveRoom(c *ChatClient)
 	BroadcastMessage(c *ChatClient, msg model.ChatMessage)
+	ModerateMember(c *ChatClient, msg model.ChatMessage)
 	Read(c *ChatClient)
 }
 
@@ -21,21 +25,58 @@ func NewChatHandler() ChatHandler {
 }
 
 func (u *chatHandler) JoinRoom(c *ChatClient, roomID uint) {
+	if err := c.Pool.Admit(roomID, c.UserId); err != nil {
+		u.sendError(c, err.Code, err.Message)
+		return
+	}
+
+	if c.RoomID != 0 && c.RoomID != roomID {
+		u.LeaveRoom(c)
+	}
+	c.Pool.Join(c, roomID)
 	c.RoomID = roomID
-	c.Pool.Register <- c
 }
 
 func (u *chatHandler) LeaveRoom(c *ChatClient) {
-	c.Pool.Unregister <- c
+	if c.RoomID == 0 {
+		return
+	}
+	c.Pool.Leave(c, c.RoomID)
+	c.RoomID = 0
 }
 
 func (u *chatHandler) BroadcastMessage(c *ChatClient, msg model.ChatMessage) {
-	msg.Sender = c.UserId
-	msg.RoomID = c.RoomID
-	msg.CreatedAt = time.Now()
+	if err := c.Pool.CanSend(c); err != nil {
+		u.sendError(c, err.Code, err.Message)
+		return
+	}
 
-	if err := c.Pool.redis.PublishToRoom(msg.RoomID, msg); err != nil {
-		fmt.Println("Error publishing message to Redis: ", err)
+	message := model.ChatMessage{
+		Type:   msg.Type,
+		Body:   msg.Body,
+		Sender: c.UserId,
+		RoomID: c.RoomID,
+	}
+	if err := c.Pool.FilterMessage(&message); err != nil {
+		u.sendError(c, err.Code, err.Message)
+		return
+	}
+	message.CreatedAt = time.Now()
+	c.Pool.SaveMessage(&message)
+
+	if err := c.Pool.bus.PublishToRoom(message.RoomID, message); err != nil {
+		fmt.Println("Error publishing chat message: ", err)
+	}
+}
+
+func (u *chatHandler) ModerateMember(c *ChatClient, msg model.ChatMessage) {
+	if msg.Target == "" {
+		u.sendError(c, model.ErrorInvalidPayload, "target is required")
+		return
+	}
+
+	if err := c.Pool.Moderate(c, msg.Type, msg.Target); err != nil {
+		u.sendError(c, moderationErrorCode(err.StatusCode), err.Message)
 	}
 }
 
@@ -46,17 +87,28 @@ func (u *chatHandler) Read(c *ChatClient) {
 	}()
 
 	for {
-		var msg model.ChatMessage
-		err := c.Conn.ReadJSON(&msg)
+		_, data, err := c.Conn.ReadMessage()
 		if err != nil {
 			fmt.Println("Read error:", err)
 			return
 		}
 
+		if !c.AllowMessage() {
+			u.sendError(c, model.ErrorRateLimited, "Too many messages, slow down")
+			continue
+		}
+
+		var msg model.ChatMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			u.sendError(c, model.ErrorInvalidPayload, "Message is not valid JSON")
+			continue
+		}
+
+		c.Pool.forgetLeftRoom(c)
 		switch msg.Type {
 		case model.JoinRoom:
 			if msg.RoomID == 0 {
-				fmt.Println("Error: Invalid Room ID received.")
+				u.sendError(c, model.ErrorInvalidPayload, "room_id is required")
 				continue
 			}
 			u.JoinRoom(c, msg.RoomID)
@@ -66,13 +118,44 @@ func (u *chatHandler) Read(c *ChatClient) {
 
 		case model.ChatContent:
 			if c.RoomID == 0 {
-				fmt.Println("Client has not joined any room")
+				u.sendError(c, model.ErrorNotJoined, "Join a room first")
 				continue
 			}
 			u.BroadcastMessage(c, msg)
 
+		case model.MuteMember, model.UnmuteMember, model.KickMember:
+			if c.RoomID == 0 {
+				u.sendError(c, model.ErrorNotJoined, "Join a room first")
+				continue
+			}
+			u.ModerateMember(c, msg)
+
 		default:
-			fmt.Println("Unknown message type:", msg.Type)
+			u.sendError(c, model.ErrorUnknownMessage, fmt.Sprintf("Unknown message type %q", msg.Type))
 		}
 	}
 }
+
+func (u *chatHandler) sendError(c *ChatClient, code string, text string) {
+	message := protocol.NewMessage(protocol.ErrorEvent{
+		RoomID:  c.RoomID,
+		UserID:  c.UserId,
+		Code:    code,
+		Message: text,
+	})
+
+	go c.WriteJSON(message)
+}
+
+func moderationErrorCode(status int) string {
+	switch status {
+	case http.StatusForbidden:
+		return model.ErrorNotHost
+	case http.StatusNotFound:
+		return model.ErrorMemberNotFound
+	case http.StatusBadRequest:
+		return model.ErrorInvalidPayload
+	default:
+		return model.ErrorModerationFailed
+	}
+}
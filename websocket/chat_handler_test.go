@@ -0,0 +1,122 @@
+package websocket
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/domain"
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+type testChatHistory struct {
+	mu       sync.Mutex
+	messages []model.ChatMessage
+}
+
+func (h *testChatHistory) SaveMessage(c *gin.Context, message *model.ChatMessage) *domain.HttpError {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	message.ID = uint(len(h.messages) + 1)
+	h.messages = append(h.messages, *message)
+	return nil
+}
+
+func (h *testChatHistory) GetRecentMessages(c *gin.Context, roomID uint, limit int) ([]model.ChatMessage, *domain.HttpError) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	recent := make([]model.ChatMessage, 0, limit)
+	for _, message := range h.messages {
+		if message.RoomID == roomID {
+			recent = append(recent, message)
+		}
+	}
+	return recent[max(len(recent)-limit, 0):], nil
+}
+
+type gatedChatHistory struct {
+	*testChatHistory
+	mu      sync.Mutex
+	gate    chan struct{}
+	loading chan struct{}
+}
+
+func (h *gatedChatHistory) GetRecentMessages(c *gin.Context, roomID uint, limit int) ([]model.ChatMessage, *domain.HttpError) {
+	messages, err := h.testChatHistory.GetRecentMessages(c, roomID, limit)
+
+	h.mu.Lock()
+	gate := h.gate
+	h.mu.Unlock()
+	if gate != nil {
+		close(h.loading)
+		<-gate
+	}
+	return messages, err
+}
+
+func TestChatReplaysHistoryOffThePoolLoopWithoutDuplicates(t *testing.T) {
+	history := &gatedChatHistory{testChatHistory: &testChatHistory{}, loading: make(chan struct{})}
+	for _, body := range []string{"first", "second"} {
+		history.SaveMessage(nil, &model.ChatMessage{Type: model.ChatContent, Body: body, RoomID: 4})
+	}
+
+	pool := NewChatPool(NewLocalMessageBus(), history, nil, nil)
+	go pool.Start()
+
+	handler := NewChatHandler()
+	sender := &ChatClient{BaseClient: BaseClient{UserId: "user:1", Outbox: make(chan any, 8)}, Pool: pool}
+	joiner := &ChatClient{BaseClient: BaseClient{UserId: "user:2", Outbox: make(chan any, 8)}, Pool: pool}
+	handler.JoinRoom(sender, 4)
+	expectChatMessage(t, sender.Outbox, "first")
+	expectChatMessage(t, sender.Outbox, "second")
+
+	gate := make(chan struct{})
+	history.mu.Lock()
+	history.gate = gate
+	history.mu.Unlock()
+
+	racing := model.ChatMessage{Type: model.ChatContent, Body: "racing", Sender: "user:1", RoomID: 4}
+	history.SaveMessage(nil, &racing)
+	handler.JoinRoom(joiner, 4)
+	<-history.loading
+
+	pool.bus.PublishToRoom(4, racing)
+	expectChatMessage(t, sender.Outbox, "racing")
+	handler.BroadcastMessage(sender, model.ChatMessage{Type: model.ChatContent, Body: "live"})
+	expectChatMessage(t, sender.Outbox, "live")
+
+	close(gate)
+	for _, body := range []string{"first", "second", "racing", "live"} {
+		expectChatMessage(t, joiner.Outbox, body)
+	}
+}
+
+func TestChatJoinReplaysHistoryAndStoresNewMessages(t *testing.T) {
+	history := &testChatHistory{}
+	for _, body := range []string{"first", "second"} {
+		history.SaveMessage(nil, &model.ChatMessage{Type: model.ChatContent, Body: body, RoomID: 4})
+	}
+	history.SaveMessage(nil, &model.ChatMessage{Type: model.ChatContent, Body: "other room", RoomID: 5})
+
+	pool := NewChatPool(NewLocalMessageBus(), history, nil, nil)
+	go pool.Start()
+
+	handler := NewChatHandler()
+	client := &ChatClient{
+		BaseClient: BaseClient{UserId: "user:1", Outbox: make(chan any, 8)},
+		Pool:       pool,
+	}
+	handler.JoinRoom(client, 4)
+	expectChatMessage(t, client.Outbox, "first")
+	expectChatMessage(t, client.Outbox, "second")
+
+	handler.BroadcastMessage(client, model.ChatMessage{Type: model.ChatContent, Body: "hello", RoomID: 99})
+	expectChatMessage(t, client.Outbox, "hello")
+
+	saved := history.messages[len(history.messages)-1]
+	if saved.Body != "hello" || saved.RoomID != 4 || saved.Sender != "user:1" || saved.ID != 4 {
+		t.Fatalf("expected the message to be stored for room 4, got %+v", saved)
+	}
+}
@@ -0,0 +1,108 @@
+package websocket
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+type ChatError struct {
+	Code    string
+	Message string
+}
+
+type ChatFilter interface {
+	Filter(message *model.ChatMessage) *ChatError
+}
+
+type chatFilterChain []ChatFilter
+
+func NewChatFilterChain(filters ...ChatFilter) ChatFilter {
+	return chatFilterChain(filters)
+}
+
+func DefaultChatFilter() ChatFilter {
+	return NewChatFilterChain(
+		NewMaxLengthFilter(MaxChatMessageLength),
+		NewLinkFilter(),
+		NewProfanityFilter(ProfanityWords),
+	)
+}
+
+func (c chatFilterChain) Filter(message *model.ChatMessage) *ChatError {
+	for _, filter := range c {
+		if err := filter.Filter(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type maxLengthFilter struct {
+	maxLength int
+}
+
+func NewMaxLengthFilter(maxLength int) ChatFilter {
+	return &maxLengthFilter{maxLength: maxLength}
+}
+
+func (f *maxLengthFilter) Filter(message *model.ChatMessage) *ChatError {
+	message.Body = strings.TrimSpace(message.Body)
+	if message.Body == "" {
+		return &ChatError{Code: model.ErrorInvalidPayload, Message: "Message is empty"}
+	}
+	if utf8.RuneCountInString(message.Body) > f.maxLength {
+		return &ChatError{
+			Code:    model.ErrorMessageTooLong,
+			Message: fmt.Sprintf("Messages are limited to %d characters", f.maxLength),
+		}
+	}
+	return nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.(?:com|net|org|io|gg|co|me|ly|xyz|app|dev|info|biz|ru|tk)(?:/\S*)?\b`)
+
+type linkFilter struct{}
+
+func NewLinkFilter() ChatFilter {
+	return &linkFilter{}
+}
+
+func (f *linkFilter) Filter(message *model.ChatMessage) *ChatError {
+	if linkPattern.MatchString(message.Body) {
+		return &ChatError{Code: model.ErrorLinkNotAllowed, Message: "Links are not allowed in chat"}
+	}
+	return nil
+}
+
+type profanityFilter struct {
+	pattern *regexp.Regexp
+}
+
+func NewProfanityFilter(words []string) ChatFilter {
+	if len(words) == 0 {
+		return &profanityFilter{}
+	}
+
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = regexp.QuoteMeta(word)
+	}
+	return &profanityFilter{
+		pattern: regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`),
+	}
+}
+
+func (f *profanityFilter) Filter(message *model.ChatMessage) *ChatError {
+	if f.pattern == nil {
+		return nil
+	}
+
+	message.Body = f.pattern.ReplaceAllStringFunc(message.Body, func(word string) string {
+		return strings.Repeat("*", utf8.RuneCountInString(word))
+	})
+	return nil
+}
@@ -0,0 +1,241 @@
+package websocket
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/Playzio/domain"
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
+	"gorm.io/gorm"
+)
+
+type testMemberStore struct {
+	mu      sync.Mutex
+	host    string
+	members map[string]*model.RoomMember
+}
+
+func (s *testMemberStore) GetMember(c *gin.Context, roomID uint, participantID string) (*model.RoomMember, *domain.HttpError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	member, exists := s.members[participantID]
+	if !exists {
+		return nil, nil
+	}
+	copied := *member
+	return &copied, nil
+}
+
+func (s *testMemberStore) MuteMember(c *gin.Context, roomID uint, hostID string, targetID string, muted bool) *domain.HttpError {
+	return s.update(hostID, targetID, func(member *model.RoomMember) {
+		member.IsMuted = muted
+	})
+}
+
+func (s *testMemberStore) KickMember(c *gin.Context, roomID uint, hostID string, targetID string) *domain.HttpError {
+	return s.update(hostID, targetID, func(member *model.RoomMember) {
+		member.IsKicked = true
+	})
+}
+
+func (s *testMemberStore) update(hostID string, targetID string, apply func(*model.RoomMember)) *domain.HttpError {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if hostID != s.host {
+		return &domain.HttpError{StatusCode: http.StatusForbidden, Message: "Only the host can moderate this room"}
+	}
+	member, exists := s.members[targetID]
+	if !exists {
+		return &domain.HttpError{StatusCode: http.StatusNotFound, Message: "Member not found"}
+	}
+	apply(member)
+	return nil
+}
+
+func expectOutbox(t *testing.T, outbox chan any, description string, match func(any) bool) {
+	t.Helper()
+	deadline := time.After(testMessageTimeout)
+	for {
+		select {
+		case msg := <-outbox:
+			if match(msg) {
+				return
+			}
+		case <-deadline:
+			t.Fatalf("timed out waiting for %s", description)
+		}
+	}
+}
+
+func chatEvent(msgType string, target string) func(any) bool {
+	return func(msg any) bool {
+		chat, ok := msg.(model.ChatMessage)
+		return ok && chat.Type == msgType && chat.Target == target
+	}
+}
+
+func chatError(code string) func(any) bool {
+	return func(msg any) bool {
+		message, ok := msg.(model.GameMessage)
+		if !ok {
+			return false
+		}
+		event, ok := message.Payload.(protocol.ErrorEvent)
+		return ok && event.Code == code
+	}
+}
+
+func TestChatFilterChain(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+		code string
+	}{
+		{name: "masks profanity", body: "what the Fuck", want: "what the ****"},
+		{name: "keeps words that contain profanity", body: "a classic assessment", want: "a classic assessment"},
+		{name: "trims whitespace", body: "  hello  ", want: "hello"},
+		{name: "blocks urls", body: "join https://example.com/room", code: model.ErrorLinkNotAllowed},
+		{name: "blocks bare domains", body: "visit playzio.io now", code: model.ErrorLinkNotAllowed},
+		{name: "blocks long messages", body: strings.Repeat("a", MaxChatMessageLength+1), code: model.ErrorMessageTooLong},
+		{name: "blocks empty messages", body: "   ", code: model.ErrorInvalidPayload},
+	}
+
+	filter := DefaultChatFilter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := model.ChatMessage{Body: tt.body}
+			err := filter.Filter(&message)
+			if tt.code != "" {
+				if err == nil || err.Code != tt.code {
+					t.Fatalf("expected %q, got %+v", tt.code, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %+v", err)
+			}
+			if message.Body != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, message.Body)
+			}
+		})
+	}
+}
+
+func TestHostMutesAndKicksMemberFromChatAndGame(t *testing.T) {
+	hostID := uint(1)
+	memberID := uint(2)
+	room := model.Room{
+		Model:     gorm.Model{ID: 6},
+		CreatedBy: &hostID,
+		Settings:  model.DefaultGameSettings(),
+		Members: []model.RoomMember{
+			{UserID: &hostID, IsCreator: true},
+			{UserID: &memberID},
+		},
+	}
+	gamePool, server := newTestPool(t, room)
+	_, players := joinTestRoom(t, gamePool, server, 6, "user:1", "user:2")
+	hostPlayer, memberPlayer := players[0], players[1]
+
+	store := &testMemberStore{
+		host: "user:1",
+		members: map[string]*model.RoomMember{
+			"user:1": {UserID: &hostID, IsCreator: true},
+			"user:2": {UserID: &memberID},
+		},
+	}
+	chatPool := NewChatPool(NewLocalMessageBus(), nil, store, gamePool)
+	go chatPool.Start()
+
+	handler := NewChatHandler()
+	host := &ChatClient{BaseClient: BaseClient{UserId: "user:1", Outbox: make(chan any, 16)}, Pool: chatPool}
+	member := &ChatClient{BaseClient: BaseClient{UserId: "user:2", Outbox: make(chan any, 16)}, Pool: chatPool}
+	handler.JoinRoom(host, 6)
+	handler.JoinRoom(member, 6)
+
+	handler.ModerateMember(member, model.ChatMessage{Type: model.KickMember, Target: "user:1"})
+	expectOutbox(t, member.Outbox, "not_host error", chatError(model.ErrorNotHost))
+
+	handler.ModerateMember(host, model.ChatMessage{Type: model.MuteMember, Target: "user:2"})
+	expectOutbox(t, member.Outbox, "member-muted event", chatEvent(model.MemberMuted, "user:2"))
+
+	handler.BroadcastMessage(member, model.ChatMessage{Type: model.ChatContent, Body: "can you hear me"})
+	expectOutbox(t, member.Outbox, "muted error", chatError(model.ErrorMuted))
+
+	memberPlayer.send(t, model.Typing, map[string]any{"text": "hi"})
+	if code := memberPlayer.expect(t, model.Error, nil).Payload["code"]; code != model.ErrorMuted {
+		t.Fatalf("expected %q, got %v", model.ErrorMuted, code)
+	}
+
+	handler.ModerateMember(host, model.ChatMessage{Type: model.KickMember, Target: "user:2"})
+	expectOutbox(t, member.Outbox, "member-kicked event", chatEvent(model.MemberKicked, "user:2"))
+	if code := memberPlayer.expect(t, model.Error, nil).Payload["code"]; code != model.ErrorKicked {
+		t.Fatalf("expected %q, got %v", model.ErrorKicked, code)
+	}
+	hostPlayer.expect(t, model.UserLeft, fromUser("user:2"))
+
+	memberPlayer.send(t, model.Typing, map[string]any{"text": "still here"})
+	if code := memberPlayer.expect(t, model.Error, nil).Payload["code"]; code != model.ErrorNotJoined {
+		t.Fatalf("expected %q, got %v", model.ErrorNotJoined, code)
+	}
+	hostPlayer.expectNone(t, model.Typing, 200*time.Millisecond)
+
+	handler.JoinRoom(member, 6)
+	expectOutbox(t, member.Outbox, "kicked error on rejoin", chatError(model.ErrorKicked))
+
+	handler.LeaveRoom(host)
+	deadline := time.Now().Add(testMessageTimeout)
+	for chatPool.hasRestriction(chatPool.kicked, 6, "user:2") {
+		if time.Now().After(deadline) {
+			t.Fatal("kicked members were kept after the room emptied")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	handler.JoinRoom(member, 6)
+	expectOutbox(t, member.Outbox, "kicked error after the room emptied", chatError(model.ErrorKicked))
+}
+
+func TestKickedChatClientCannotPostAfterTheRoomEmpties(t *testing.T) {
+	store := &testMemberStore{
+		host: "user:1",
+		members: map[string]*model.RoomMember{
+			"user:1": {IsCreator: true},
+			"user:2": {},
+		},
+	}
+	history := &testChatHistory{}
+	pool := NewChatPool(NewLocalMessageBus(), history, store, nil)
+	go pool.Start()
+
+	handler := NewChatHandler()
+	host := &ChatClient{BaseClient: BaseClient{UserId: "user:1", Outbox: make(chan any, 16)}, Pool: pool}
+	member := &ChatClient{BaseClient: BaseClient{UserId: "user:2", Outbox: make(chan any, 16)}, Pool: pool}
+	handler.JoinRoom(host, 7)
+	handler.JoinRoom(member, 7)
+
+	handler.ModerateMember(host, model.ChatMessage{Type: model.KickMember, Target: "user:2"})
+	expectOutbox(t, member.Outbox, "member-kicked event", chatEvent(model.MemberKicked, "user:2"))
+
+	handler.LeaveRoom(host)
+	handler.JoinRoom(host, 7)
+
+	handler.BroadcastMessage(member, model.ChatMessage{Type: model.ChatContent, Body: "still here"})
+	expectOutbox(t, member.Outbox, "not_joined error", chatError(model.ErrorNotJoined))
+	if messages, _ := history.GetRecentMessages(nil, 7, ChatHistoryReplaySize); len(messages) != 0 {
+		t.Fatalf("expected the kicked member's message to be dropped, got %+v", messages)
+	}
+
+	pool.forgetLeftRoom(member)
+	if member.RoomID != 0 {
+		t.Fatalf("expected the kicked member to lose its room, got %d", member.RoomID)
+	}
+}
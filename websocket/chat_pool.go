@@ -2,18 +2,20 @@ package websocket
 
 import (
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
-	"github.com/lakshya1goel/Playzio/bootstrap/redis"
+	"github.com/gin-gonic/gin"
 	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain"
 	"github.com/lakshya1goel/Playzio/domain/model"
 )
 
 type BasePool[T any] struct {
 	Register   chan T
 	Unregister chan T
-	Rooms      map[uint]map[uint]T
+	Rooms      map[uint]map[string]T
 	Broadcast  chan any
 	mu         sync.RWMutex
 }
@@ -22,33 +24,73 @@ func NewBasePool[T any]() *BasePool[T] {
 	return &BasePool[T]{
 		Register:   make(chan T),
 		Unregister: make(chan T),
-		Rooms:      make(map[uint]map[uint]T),
+		Rooms:      make(map[uint]map[string]T),
 		Broadcast:  make(chan any),
 	}
 }
 
+type ChatHistory interface {
+	SaveMessage(c *gin.Context, message *model.ChatMessage) *domain.HttpError
+	GetRecentMessages(c *gin.Context, roomID uint, limit int) ([]model.ChatMessage, *domain.HttpError)
+}
+
+type MemberStore interface {
+	GetMember(c *gin.Context, roomID uint, participantID string) (*model.RoomMember, *domain.HttpError)
+	MuteMember(c *gin.Context, roomID uint, hostID string, targetID string, muted bool) *domain.HttpError
+	KickMember(c *gin.Context, roomID uint, hostID string, targetID string) *domain.HttpError
+}
+
+type RoomModerator interface {
+	KickFromRoom(roomID uint, userID string)
+	MuteInRoom(roomID uint, userID string, muted bool)
+}
+
+type chatMembership struct {
+	client *ChatClient
+	roomID uint
+	done   chan struct{}
+}
+
 type ChatPool struct {
 	*BasePool[*ChatClient]
+	joins             chan chatMembership
+	leaves            chan chatMembership
 	roomSubscriptions map[uint]bool
-	redis             *redis.Redis
+	bus               MessageBus
+	history           ChatHistory
+	members           MemberStore
+	games             RoomModerator
+	filter            ChatFilter
+	muted             map[uint]map[string]bool
+	kicked            map[uint]map[string]bool
 }
 
-func NewChatPool(redisClient *redis.Redis) *ChatPool {
+func NewChatPool(bus MessageBus, history ChatHistory, members MemberStore, games RoomModerator) *ChatPool {
 	return &ChatPool{
 		BasePool:          NewBasePool[*ChatClient](),
+		joins:             make(chan chatMembership),
+		leaves:            make(chan chatMembership),
 		roomSubscriptions: make(map[uint]bool),
-		redis:             redisClient,
+		bus:               bus,
+		history:           history,
+		members:           members,
+		games:             games,
+		filter:            DefaultChatFilter(),
+		muted:             make(map[uint]map[string]bool),
+		kicked:            make(map[uint]map[string]bool),
 	}
 }
 
 func (p *ChatPool) Start() {
 	for {
 		select {
-		case client := <-p.Register:
-			p.handleClientRegister(client)
+		case request := <-p.joins:
+			p.handleClientRegister(request.client, request.roomID)
+			close(request.done)
 
-		case client := <-p.Unregister:
-			p.handleClientUnregister(client)
+		case request := <-p.leaves:
+			p.handleClientUnregister(request.client, request.roomID)
+			close(request.done)
 
 		case raw := <-p.Broadcast:
 			if !p.handleBroadcast(raw) {
@@ -58,38 +100,261 @@ func (p *ChatPool) Start() {
 	}
 }
 
-func (p *ChatPool) handleClientRegister(c *ChatClient) {
-	util.RegisterClient(&p.mu, p.Rooms, c.RoomID, c.UserId, c)
-	if !p.roomSubscriptions[c.RoomID] {
-		if err := p.redis.SubscribeToRoom(c.RoomID, func(msg model.ChatMessage) {
+func (p *ChatPool) Join(c *ChatClient, roomID uint) {
+	done := make(chan struct{})
+	p.joins <- chatMembership{client: c, roomID: roomID, done: done}
+	<-done
+}
+
+func (p *ChatPool) Leave(c *ChatClient, roomID uint) {
+	done := make(chan struct{})
+	p.leaves <- chatMembership{client: c, roomID: roomID, done: done}
+	<-done
+}
+
+func (p *ChatPool) handleClientRegister(c *ChatClient, roomID uint) {
+	p.mu.RLock()
+	previous := p.Rooms[roomID][c.UserId]
+	p.mu.RUnlock()
+	if previous == c {
+		return
+	}
+	if previous != nil {
+		close(previous.queue)
+	}
+
+	c.queue = make(chan any, ChatClientBufferSize)
+	go p.deliver(c, roomID, c.queue)
+	util.RegisterClient(&p.mu, p.Rooms, roomID, c.UserId, c)
+	if !p.roomSubscriptions[roomID] {
+		if err := p.bus.SubscribeToRoom(roomID, func(msg model.ChatMessage) {
 			p.Broadcast <- msg
 		}); err != nil {
 			fmt.Println("Error subscribing to room: ", err)
 		}
-		p.roomSubscriptions[c.RoomID] = true
+		p.roomSubscriptions[roomID] = true
+	}
+}
+
+func (p *ChatPool) SaveMessage(msg *model.ChatMessage) {
+	if p.history == nil {
+		return
+	}
+	if err := p.history.SaveMessage(nil, msg); err != nil {
+		fmt.Println("Error saving chat message: ", err.Message)
+	}
+}
+
+func (p *ChatPool) deliver(c *ChatClient, roomID uint, queue chan any) {
+	replayed := p.replayHistory(c, roomID)
+	for msg := range queue {
+		if chat, ok := msg.(model.ChatMessage); ok && replayed[chat.ID] {
+			continue
+		}
+		c.WriteJSON(msg)
+	}
+}
+
+func (p *ChatPool) send(c *ChatClient, msg any) {
+	select {
+	case c.queue <- msg:
+	default:
+		fmt.Println("Dropping chat message for slow client: ", c.UserId)
 	}
 }
 
-func (p *ChatPool) handleClientUnregister(c *ChatClient) {
-	util.UnregisterClient(&p.mu, p.Rooms, c.RoomID, c.UserId)
-	if len(p.Rooms[c.RoomID]) == 0 {
-		if err := p.redis.UnsubscribeFromRoom(c.RoomID); err != nil {
+func (p *ChatPool) replayHistory(c *ChatClient, roomID uint) map[uint]bool {
+	if p.history == nil {
+		return nil
+	}
+
+	messages, err := p.history.GetRecentMessages(nil, roomID, ChatHistoryReplaySize)
+	if err != nil {
+		fmt.Println("Error loading chat history: ", err.Message)
+		return nil
+	}
+
+	replayed := make(map[uint]bool, len(messages))
+	for _, msg := range messages {
+		c.WriteJSON(msg)
+		if msg.ID != 0 {
+			replayed[msg.ID] = true
+		}
+	}
+	return replayed
+}
+
+func (p *ChatPool) Admit(roomID uint, userID string) *ChatError {
+	if p.hasRestriction(p.kicked, roomID, userID) {
+		return &ChatError{Code: model.ErrorKicked, Message: "You have been removed from this room"}
+	}
+	if p.members == nil {
+		return nil
+	}
+
+	member, err := p.members.GetMember(nil, roomID, userID)
+	if err != nil {
+		fmt.Println("Error loading room member: ", err.Message)
+		return nil
+	}
+	if member == nil {
+		return nil
+	}
+	if member.IsKicked {
+		p.setRestriction(p.kicked, roomID, userID, true)
+		return &ChatError{Code: model.ErrorKicked, Message: "You have been removed from this room"}
+	}
+	p.setRestriction(p.muted, roomID, userID, member.IsMuted)
+	return nil
+}
+
+func (p *ChatPool) IsMember(c *ChatClient) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Rooms[c.RoomID][c.UserId] == c
+}
+
+func (p *ChatPool) forgetLeftRoom(c *ChatClient) {
+	if c.RoomID != 0 && !p.IsMember(c) {
+		c.RoomID = 0
+	}
+}
+
+func (p *ChatPool) CanSend(c *ChatClient) *ChatError {
+	if !p.IsMember(c) {
+		return &ChatError{Code: model.ErrorNotJoined, Message: "Join a room first"}
+	}
+	if p.hasRestriction(p.kicked, c.RoomID, c.UserId) {
+		return &ChatError{Code: model.ErrorKicked, Message: "You have been removed from this room"}
+	}
+	if p.hasRestriction(p.muted, c.RoomID, c.UserId) {
+		return &ChatError{Code: model.ErrorMuted, Message: "You are muted in this room"}
+	}
+	return nil
+}
+
+func (p *ChatPool) FilterMessage(msg *model.ChatMessage) *ChatError {
+	if p.filter == nil {
+		return nil
+	}
+	return p.filter.Filter(msg)
+}
+
+func (p *ChatPool) Moderate(c *ChatClient, action string, target string) *domain.HttpError {
+	if p.members == nil {
+		return &domain.HttpError{
+			StatusCode: http.StatusServiceUnavailable,
+			Message:    "Moderation is unavailable",
+		}
+	}
+
+	event := model.ChatMessage{
+		Sender: c.UserId,
+		RoomID: c.RoomID,
+		Target: target,
+	}
+
+	var err *domain.HttpError
+	switch action {
+	case model.MuteMember, model.UnmuteMember:
+		muted := action == model.MuteMember
+		if err = p.members.MuteMember(nil, c.RoomID, c.UserId, target, muted); err == nil && p.games != nil {
+			p.games.MuteInRoom(c.RoomID, target, muted)
+		}
+		event.Type = model.MemberUnmuted
+		if muted {
+			event.Type = model.MemberMuted
+		}
+	case model.KickMember:
+		if err = p.members.KickMember(nil, c.RoomID, c.UserId, target); err == nil && p.games != nil {
+			p.games.KickFromRoom(c.RoomID, target)
+		}
+		event.Type = model.MemberKicked
+	}
+	if err != nil {
+		return err
+	}
+
+	event.CreatedAt = time.Now()
+	if err := p.bus.PublishToRoom(c.RoomID, event); err != nil {
+		fmt.Println("Error publishing moderation event: ", err)
+	}
+	return nil
+}
+
+func (p *ChatPool) hasRestriction(restrictions map[uint]map[string]bool, roomID uint, userID string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return restrictions[roomID][userID]
+}
+
+func (p *ChatPool) setRestriction(restrictions map[uint]map[string]bool, roomID uint, userID string, restricted bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !restricted {
+		delete(restrictions[roomID], userID)
+		if len(restrictions[roomID]) == 0 {
+			delete(restrictions, roomID)
+		}
+		return
+	}
+	if restrictions[roomID] == nil {
+		restrictions[roomID] = make(map[string]bool)
+	}
+	restrictions[roomID][userID] = true
+}
+
+func (p *ChatPool) handleClientUnregister(c *ChatClient, roomID uint) {
+	p.mu.Lock()
+	if p.Rooms[roomID][c.UserId] != c {
+		p.mu.Unlock()
+		return
+	}
+	delete(p.Rooms[roomID], c.UserId)
+	close(c.queue)
+	empty := len(p.Rooms[roomID]) == 0
+	if empty {
+		delete(p.Rooms, roomID)
+		delete(p.muted, roomID)
+		delete(p.kicked, roomID)
+	}
+	p.mu.Unlock()
+
+	if empty {
+		if err := p.bus.UnsubscribeFromRoom(roomID); err != nil {
 			fmt.Println("Error unsubscribing from room: ", err)
 		}
-		delete(p.roomSubscriptions, c.RoomID)
+		delete(p.roomSubscriptions, roomID)
 	}
 }
+
 func (p *ChatPool) handleBroadcast(raw interface{}) bool {
 	msg, ok := raw.(model.ChatMessage)
 	if !ok {
 		return false
 	}
-	msg.CreatedAt = time.Now()
+	if msg.CreatedAt.IsZero() {
+		msg.CreatedAt = time.Now()
+	}
+
+	switch msg.Type {
+	case model.MemberMuted, model.MemberUnmuted:
+		p.setRestriction(p.muted, msg.RoomID, msg.Target, msg.Type == model.MemberMuted)
+	case model.MemberKicked:
+		p.setRestriction(p.kicked, msg.RoomID, msg.Target, true)
+	}
+
 	p.mu.RLock()
 	clients := p.Rooms[msg.RoomID]
 	for _, client := range clients {
-		go client.WriteJSON(msg)
+		p.send(client, msg)
 	}
+	kicked := clients[msg.Target]
 	p.mu.RUnlock()
+
+	if msg.Type == model.MemberKicked && kicked != nil {
+		p.handleClientUnregister(kicked, msg.RoomID)
+	}
 	return true
 }
@@ -5,15 +5,17 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
-	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
 )
 
 type BaseClient struct {
 	Conn         *websocket.Conn
-	UserId       uint
+	UserId       string
 	UserName     string
 	RoomID       uint
 	mu           sync.Mutex
+	pingMu       sync.Mutex
+	stopPing     chan struct{}
 	LastPongTime time.Time
 	PingInterval time.Duration
 	PongTimeout  time.Duration
@@ -21,35 +23,61 @@ type BaseClient struct {
 	PongTimer    *time.Timer
 	IsConnected  bool
 	PingCount    int
+	Outbox       chan any
+	Forward      func(v any) error
+	rateWindow   time.Time
+	rateCount    int
 }
 
 func (bc *BaseClient) WriteJSON(v any) error {
+	if bc.Forward != nil {
+		return bc.Forward(v)
+	}
+	if bc.Outbox != nil {
+		select {
+		case bc.Outbox <- v:
+		default:
+		}
+		return nil
+	}
+
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 	return bc.Conn.WriteJSON(v)
 }
 
+func (bc *BaseClient) AllowMessage() bool {
+	now := time.Now()
+	if now.Sub(bc.rateWindow) >= MessageRateWindow {
+		bc.rateWindow = now
+		bc.rateCount = 0
+	}
+	bc.rateCount++
+	return bc.rateCount <= MessageRateLimit
+}
+
 func (bc *BaseClient) SendPing() error {
 	bc.PingCount++
-	message := NewGameMessage().
-		SetMessageType(model.Ping).
-		WithTimestamp(time.Now().Unix()).
-		WithPingId(bc.PingCount).
-		Build()
+	message := protocol.NewMessage(protocol.PingEvent{
+		Timestamp: time.Now().Unix(),
+		PingID:    bc.PingCount,
+	})
 
 	return bc.WriteJSON(message)
 }
 
 func (bc *BaseClient) SendPong(timestamp int64) error {
-	message := NewGameMessage().
-		SetMessageType(model.Pong).
-		WithTimestamp(timestamp).
-		Build()
+	message := protocol.NewMessage(protocol.PongEvent{
+		Timestamp: timestamp,
+	})
 
 	return bc.WriteJSON(message)
 }
 
 func (bc *BaseClient) StartPingPong() {
+	bc.pingMu.Lock()
+	defer bc.pingMu.Unlock()
+
 	bc.PingInterval = PingInterval
 	bc.PongTimeout = PongTimeout
 	bc.IsConnected = true
@@ -58,31 +86,36 @@ func (bc *BaseClient) StartPingPong() {
 
 	bc.PingTicker = time.NewTicker(bc.PingInterval)
 	bc.PongTimer = time.NewTimer(bc.PongTimeout)
+	bc.stopPing = make(chan struct{})
 
-	go bc.pingLoop()
+	go bc.pingLoop(bc.PingTicker, bc.stopPing)
 }
 
-func (bc *BaseClient) pingLoop() {
-	for bc.IsConnected {
+func (bc *BaseClient) pingLoop(ticker *time.Ticker, stop chan struct{}) {
+	for {
 		select {
-		case <-bc.PingTicker.C:
+		case <-stop:
+			return
+
+		case <-ticker.C:
 			if err := bc.SendPing(); err != nil {
-				bc.IsConnected = false
+				bc.StopPingPong()
 				return
 			}
-			if bc.PongTimer != nil {
-				bc.PongTimer.Stop()
-			}
-			bc.PongTimer = time.NewTimer(bc.PongTimeout)
-
-		case <-bc.PongTimer.C:
-			bc.PongTimer = time.NewTimer(bc.PongTimeout)
+			bc.resetPongTimer()
 		}
 	}
 }
 
 func (bc *BaseClient) StopPingPong() {
+	bc.pingMu.Lock()
+	defer bc.pingMu.Unlock()
+
+	if !bc.IsConnected {
+		return
+	}
 	bc.IsConnected = false
+	close(bc.stopPing)
 	if bc.PingTicker != nil {
 		bc.PingTicker.Stop()
 	}
@@ -92,19 +125,38 @@ func (bc *BaseClient) StopPingPong() {
 }
 
 func (bc *BaseClient) HandlePong(timestamp int64) {
+	bc.pingMu.Lock()
 	bc.LastPongTime = time.Now()
+	bc.pingMu.Unlock()
+
+	bc.resetPongTimer()
+}
+
+func (bc *BaseClient) resetPongTimer() {
+	bc.pingMu.Lock()
+	defer bc.pingMu.Unlock()
+
+	if !bc.IsConnected {
+		return
+	}
 	if bc.PongTimer != nil {
 		bc.PongTimer.Stop()
-		bc.PongTimer = time.NewTimer(bc.PongTimeout)
 	}
+	bc.PongTimer = time.NewTimer(bc.PongTimeout)
 }
 
 type ChatClient struct {
 	BaseClient
-	Pool *ChatPool
+	Pool  *ChatPool
+	queue chan any
 }
 
 type GameClient struct {
 	BaseClient
-	Pool *GamePool
+	Pool            *GamePool
+	ResumeToken     string
+	Spectator       bool
+	IsBot           bool
+	ProtocolVersion int
+	Node            string
 }
@@ -0,0 +1,321 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
+)
+
+type RoomBus interface {
+	Publish(channel string, data []byte) error
+	Subscribe(channel string, handler func([]byte)) error
+	AcquireLease(key string, owner string, ttl time.Duration) (string, error)
+	LeaseOwner(key string) (string, error)
+	ReleaseLease(key string, owner string) error
+}
+
+const (
+	busInbound    = "inbound"
+	busDisconnect = "disconnect"
+	busDeliver    = "deliver"
+	busKick       = "kick"
+	busMute       = "mute"
+)
+
+type busEnvelope struct {
+	Kind     string          `json:"kind"`
+	Node     string          `json:"node"`
+	RoomID   uint            `json:"room_id"`
+	UserID   string          `json:"user_id,omitempty"`
+	UserName string          `json:"user_name,omitempty"`
+	UserIDs  []string        `json:"user_ids,omitempty"`
+	Muted    bool            `json:"muted,omitempty"`
+	Message  json.RawMessage `json:"message,omitempty"`
+}
+
+func nodeChannel(nodeID string) string {
+	return "game:node:" + nodeID
+}
+
+func roomLeaseKey(roomID uint) string {
+	return fmt.Sprintf("game:room:%d:owner", roomID)
+}
+
+func (p *GamePool) NodeID() string {
+	return p.nodeID
+}
+
+func (p *GamePool) startBus() {
+	if p.bus == nil {
+		return
+	}
+
+	if err := p.bus.Subscribe(nodeChannel(p.nodeID), p.handleBusMessage); err != nil {
+		fmt.Println("Error subscribing to game node channel:", err)
+	}
+	go p.renewLeases()
+}
+
+func (p *GamePool) renewLeases() {
+	ticker := time.NewTicker(RoomLeaseRenewInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		p.renewOwnedRooms()
+	}
+}
+
+func (p *GamePool) renewOwnedRooms() {
+	for _, roomID := range p.gameStateManager.RoomIDs() {
+		owner, err := p.bus.AcquireLease(roomLeaseKey(roomID), p.nodeID, p.leaseTTL)
+		if err != nil {
+			continue
+		}
+		if owner != p.nodeID {
+			fmt.Println("Lost ownership of room", roomID, "to node", owner)
+			p.roomLost <- roomID
+		}
+	}
+}
+
+func (p *GamePool) roomOwner(roomID uint) string {
+	if p.gameStateManager.GetRoom(roomID) != nil {
+		return p.nodeID
+	}
+
+	owner, err := p.bus.AcquireLease(roomLeaseKey(roomID), p.nodeID, p.leaseTTL)
+	if err != nil {
+		return p.nodeID
+	}
+	return owner
+}
+
+func (p *GamePool) remoteOwner(roomID uint) string {
+	if p.bus == nil || p.gameStateManager.GetRoom(roomID) != nil {
+		return ""
+	}
+
+	owner, err := p.bus.LeaseOwner(roomLeaseKey(roomID))
+	if err != nil || owner == p.nodeID {
+		return ""
+	}
+	return owner
+}
+
+func (p *GamePool) releaseRoom(roomID uint) {
+	if p.bus == nil {
+		return
+	}
+	p.bus.ReleaseLease(roomLeaseKey(roomID), p.nodeID)
+}
+
+func (p *GamePool) relay(c *GameClient, payload protocol.InboundPayload) bool {
+	if p.bus == nil || c.Node != "" {
+		return false
+	}
+
+	roomID := c.RoomID
+	switch request := payload.(type) {
+	case *protocol.PingRequest, *protocol.PongRequest:
+		return false
+	case *protocol.JoinRequest:
+		roomID = request.RoomID
+	}
+	if roomID == 0 {
+		return false
+	}
+
+	_, joining := payload.(*protocol.JoinRequest)
+	owner := p.roomOwner(roomID)
+	if owner == p.nodeID {
+		if joining {
+			p.dropRelayed(c)
+		}
+		return false
+	}
+
+	message, err := json.Marshal(model.GameMessage{
+		Type:    payload.MessageType(),
+		Payload: payload,
+	})
+	if err != nil {
+		fmt.Println("Error encoding relayed message:", err)
+		return true
+	}
+
+	switch payload.(type) {
+	case *protocol.JoinRequest:
+		p.dropRelayed(c)
+		c.RoomID = roomID
+		util.RegisterClient(&p.mu, p.relayed, roomID, c.UserId, c)
+	case *protocol.LeaveRequest:
+		p.dropRelayed(c)
+	}
+
+	p.publish(owner, busEnvelope{
+		Kind:     busInbound,
+		RoomID:   roomID,
+		UserID:   c.UserId,
+		UserName: c.UserName,
+		Message:  message,
+	})
+	return true
+}
+
+func (p *GamePool) relayDisconnect(c *GameClient) {
+	owner := p.remoteOwner(c.RoomID)
+	if owner == "" {
+		return
+	}
+
+	p.publish(owner, busEnvelope{
+		Kind:   busDisconnect,
+		RoomID: c.RoomID,
+		UserID: c.UserId,
+	})
+}
+
+func (p *GamePool) dropRelayed(c *GameClient) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.relayed[c.RoomID][c.UserId] != c {
+		return false
+	}
+	delete(p.relayed[c.RoomID], c.UserId)
+	if len(p.relayed[c.RoomID]) == 0 {
+		delete(p.relayed, c.RoomID)
+	}
+	return true
+}
+
+func (p *GamePool) deliverRemote(node string, roomID uint, userIDs []string, msg any) {
+	message, err := json.Marshal(msg)
+	if err != nil {
+		fmt.Println("Error encoding remote message:", err)
+		return
+	}
+
+	p.publish(node, busEnvelope{
+		Kind:    busDeliver,
+		RoomID:  roomID,
+		UserIDs: userIDs,
+		Message: message,
+	})
+}
+
+func (p *GamePool) publish(node string, envelope busEnvelope) {
+	envelope.Node = p.nodeID
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		fmt.Println("Error encoding bus envelope:", err)
+		return
+	}
+
+	if err := p.bus.Publish(nodeChannel(node), data); err != nil {
+		fmt.Println("Error publishing to node", node, ":", err)
+	}
+}
+
+func (p *GamePool) handleBusMessage(data []byte) {
+	var envelope busEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		fmt.Println("Error decoding bus envelope:", err)
+		return
+	}
+
+	switch envelope.Kind {
+	case busInbound:
+		p.handleRelayedMessage(envelope)
+	case busDisconnect:
+		if proxy := p.proxy(envelope); proxy != nil {
+			p.DisconnectClient(proxy)
+		}
+	case busDeliver:
+		p.deliverLocal(envelope)
+	case busKick:
+		p.kick(envelope.RoomID, envelope.UserID)
+	case busMute:
+		p.setMuted(envelope.RoomID, envelope.UserID, envelope.Muted)
+	default:
+		fmt.Println("Unknown bus envelope kind:", envelope.Kind)
+	}
+}
+
+func (p *GamePool) handleRelayedMessage(envelope busEnvelope) {
+	payload, decodeErr := protocol.DecodeInbound(envelope.Message)
+	if decodeErr != nil {
+		fmt.Println("Error decoding relayed message:", decodeErr.Message)
+		return
+	}
+
+	proxy := p.proxy(envelope)
+	if _, joining := payload.(*protocol.JoinRequest); joining || proxy == nil {
+		proxy = p.newProxy(envelope)
+	}
+	p.HandleMessage(proxy, payload)
+}
+
+func (p *GamePool) deliverLocal(envelope busEnvelope) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, userID := range envelope.UserIDs {
+		if client := p.relayed[envelope.RoomID][userID]; client != nil {
+			go client.WriteJSON(envelope.Message)
+		}
+	}
+}
+
+func (p *GamePool) newProxy(envelope busEnvelope) *GameClient {
+	node, roomID, userID := envelope.Node, envelope.RoomID, envelope.UserID
+	proxy := &GameClient{
+		BaseClient: BaseClient{
+			UserId:   userID,
+			UserName: envelope.UserName,
+			RoomID:   roomID,
+			Forward: func(v any) error {
+				p.deliverRemote(node, roomID, []string{userID}, v)
+				return nil
+			},
+		},
+		Pool: p,
+		Node: node,
+	}
+
+	p.mu.Lock()
+	p.proxies[proxyKey(node, userID)] = proxy
+	p.mu.Unlock()
+	return proxy
+}
+
+func (p *GamePool) proxy(envelope busEnvelope) *GameClient {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	proxy := p.proxies[proxyKey(envelope.Node, envelope.UserID)]
+	if proxy == nil || proxy.RoomID != envelope.RoomID {
+		return nil
+	}
+	return proxy
+}
+
+func (p *GamePool) forgetProxy(c *GameClient) {
+	if c.Node == "" {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.proxies[proxyKey(c.Node, c.UserId)] == c {
+		delete(p.proxies, proxyKey(c.Node, c.UserId))
+	}
+}
+
+func proxyKey(node string, userID string) string {
+	return node + "/" + userID
+}
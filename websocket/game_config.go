@@ -3,23 +3,33 @@ package websocket
 import "time"
 
 const (
-	MaxRoomCapacity = 10
+	MaxSpectators       = 20
+	RoomEventBufferSize = 64
 )
 
 const (
-	CountdownDuration = 2 * time.Minute
-	RoundMaxTimeLimit = 20
-	MinTimeLimit      = 5
-	InitialTimeLimit  = 19
+	DefaultCountdownDuration = 10 * time.Second
+	ExtendCountdownDuration  = 30 * time.Second
+	MaxCountdownDuration     = 2 * time.Minute
+	GameSaveTimeout          = 10 * time.Second
 )
 
 const (
-	InitialLives     = 3
 	InitialRound     = 1
 	InitialPoints    = 0
 	InitialTurnIndex = 0
 )
 
+const (
+	FlatScore          = 1
+	ScoreBase          = 10
+	ScoreLengthBonus   = 2
+	ScoreFreeLetters   = 3
+	ScoreMaxDifficulty = 10
+	ScoreMaxSpeed      = 10
+	ScoreMaxRarity     = 10
+)
+
 const (
 	MinAlivePlayersForGameEnd = 1
 	MaxScoreForComparison     = -1
@@ -29,3 +39,81 @@ const (
 	PingInterval = 30 * time.Second
 	PongTimeout  = 10 * time.Second
 )
+
+const (
+	MessageRateLimit  = 20
+	MessageRateWindow = time.Second
+)
+
+const (
+	ChatHistoryReplaySize = 50
+	MaxChatMessageLength  = 500
+	ChatClientBufferSize  = 64
+)
+
+var ProfanityWords = []string{
+	"arse",
+	"arsehole",
+	"ass",
+	"asshole",
+	"bastard",
+	"bitch",
+	"bollocks",
+	"bullshit",
+	"cunt",
+	"dick",
+	"fuck",
+	"fucker",
+	"fucking",
+	"motherfucker",
+	"piss",
+	"prick",
+	"shit",
+	"shitty",
+	"slut",
+	"twat",
+	"wanker",
+	"whore",
+}
+
+const (
+	BotEasy              = "easy"
+	BotMedium            = "medium"
+	BotHard              = "hard"
+	DefaultBotDifficulty = BotMedium
+	BotOutboxSize        = 64
+)
+
+var BotDifficulties = map[string]BotDifficulty{
+	BotEasy: {
+		AnswerProbability: 0.6,
+		MinDelay:          4 * time.Second,
+		MaxDelay:          8 * time.Second,
+		VocabularySize:    2000,
+	},
+	BotMedium: {
+		AnswerProbability: 0.8,
+		MinDelay:          2 * time.Second,
+		MaxDelay:          5 * time.Second,
+		VocabularySize:    10000,
+	},
+	BotHard: {
+		AnswerProbability: 0.95,
+		MinDelay:          1 * time.Second,
+		MaxDelay:          3 * time.Second,
+		VocabularySize:    0,
+	},
+}
+
+const (
+	ReconnectGracePeriod = 30 * time.Second
+)
+
+const (
+	BusHealthCheckInterval = 5 * time.Second
+)
+
+const (
+	RoomLeaseTTL           = 15 * time.Second
+	RoomLeaseRenewInterval = 5 * time.Second
+)
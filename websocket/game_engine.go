@@ -1,38 +1,101 @@
 package websocket
 
 import (
-	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/lakshya1goel/Playzio/bootstrap/dictionary"
 	"github.com/lakshya1goel/Playzio/bootstrap/util"
 	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
 )
 
 type GameEngine interface {
+	StartGame()
 	StartNextTurn()
-	countAlivePlayers() int
-	startTurn(userID uint)
-	endGame(winnerID uint)
-	checkEndCondition() bool
-	getFinalScores() map[string]any
-	handleSuccessfulAnswer(userID uint, answer string, newCharSet string)
-	handleWrongAnswer(userID uint, answer string)
+	HandleAnswer(c *GameClient, answer string)
+	HandleTurnTimeout(turnID int)
+	RemovePlayer(userID string)
+	Stop()
 }
 
 type gameEngine struct {
-	Pool              *GamePool
-	GameRoomState     *model.GameRoomState
-	RoundMaxTimeLimit int
-	MinTimeLimit      int
+	Pool          *GamePool
+	Room          *GameRoom
+	GameRoomState *model.GameRoomState
+	Dictionary    dictionary.Dictionary
+	Scorer        Scorer
 }
 
-func NewGameEngine(pool *GamePool, room *model.GameRoomState) GameEngine {
+func NewGameEngine(pool *GamePool, room *GameRoom) GameEngine {
+	if room.State.Settings.Mode == model.ModeRace {
+		return NewRaceEngine(pool, room)
+	}
+	return newGameEngine(pool, room)
+}
+
+func newGameEngine(pool *GamePool, room *GameRoom) *gameEngine {
 	return &gameEngine{
-		Pool:              pool,
-		GameRoomState:     room,
-		RoundMaxTimeLimit: RoundMaxTimeLimit,
-		MinTimeLimit:      MinTimeLimit,
+		Pool:          pool,
+		Room:          room,
+		GameRoomState: room.State,
+		Dictionary:    pool.Dictionary(room.State.Language),
+		Scorer:        NewScorer(room.State.Settings.Scoring),
+	}
+}
+
+func (g *gameEngine) StartGame() {
+	if g.GameRoomState.Started {
+		return
+	}
+
+	g.resetGame()
+	g.broadcastStartGame()
+	g.StartNextTurn()
+}
+
+func (g *gameEngine) resetGame() {
+	g.GameRoomState.Started = true
+	g.GameRoomState.StartedAt = time.Now()
+	g.GameRoomState.Round = InitialRound
+	g.GameRoomState.CharSet = g.Dictionary.GeneratePrompt(g.GameRoomState.Round)
+	g.GameRoomState.TimeLimit = g.turnTimeLimit()
+	g.GameRoomState.CountdownStarted = false
+	g.GameRoomState.TurnIndex = g.GameRoomState.StartingTurn % len(g.GameRoomState.Players)
+	g.GameRoomState.UsedWords = []string{}
+	g.GameRoomState.UsedWordSet = make(map[string]bool)
+	g.GameRoomState.WordsAnswered = make(map[string]int)
+	g.GameRoomState.LivesLost = make(map[string]int)
+	g.GameRoomState.LettersUsed = make(map[string]map[string]bool)
+	g.GameRoomState.ScoreBreakdowns = make(map[string]model.ScoreBreakdown)
+	g.Scorer = NewScorer(g.GameRoomState.Settings.Scoring)
+	g.GameRoomState.WinningTeam = model.NoTeam
+	for _, uid := range g.GameRoomState.Players {
+		g.GameRoomState.Lives[uid] = g.GameRoomState.Settings.Lives
+		g.GameRoomState.Points[uid] = InitialPoints
+	}
+	if g.isTeamMode() {
+		g.assignTeams()
+	}
+	g.GameRoomState.Turns = []model.GameTurn{}
+}
+
+func (g *gameEngine) broadcastStartGame() {
+	event := protocol.StartGameEvent{
+		RoomID:    g.GameRoomState.RoomID,
+		CharSet:   g.GameRoomState.CharSet,
+		Round:     g.GameRoomState.Round,
+		TimeLimit: g.GameRoomState.TimeLimit,
+	}
+
+	if g.isTeamMode() {
+		event.Teams = maps.Clone(g.GameRoomState.Teams)
 	}
+
+	g.Pool.BroadcastToRoom(g.GameRoomState.RoomID, protocol.NewMessage(event))
 }
 
 func (g *gameEngine) StartNextTurn() {
@@ -44,6 +107,11 @@ func (g *gameEngine) StartNextTurn() {
 		return
 	}
 
+	if g.isTeamMode() {
+		g.startNextTeamTurn()
+		return
+	}
+
 	originalTurnIndex := g.GameRoomState.TurnIndex
 	roundIncremented := false
 
@@ -61,6 +129,156 @@ func (g *gameEngine) StartNextTurn() {
 	}
 }
 
+func (g *gameEngine) HandleAnswer(c *GameClient, answer string) {
+	if !g.GameRoomState.Started {
+		g.Pool.SendError(c, model.ErrorGameNotStarted, "The game has not started")
+		return
+	}
+
+	if g.GameRoomState.Players[g.GameRoomState.TurnIndex] != c.UserId {
+		g.Pool.SendError(c, model.ErrorNotYourTurn, "It is not your turn")
+		return
+	}
+
+	if reason := g.validateAnswer(answer); reason != "" {
+		g.broadcastAnswer(c.UserId, answer, false, reason, nil)
+		g.handleWrongAnswer(c.UserId, answer)
+		return
+	}
+
+	word := strings.ToLower(answer)
+	breakdown := g.scoreAnswer(word)
+	g.GameRoomState.UsedWordSet[word] = true
+	g.GameRoomState.UsedWords = append(g.GameRoomState.UsedWords, word)
+	g.GameRoomState.WordsAnswered[c.UserId]++
+	g.recordTurn(c.UserId, word, model.ReasonCorrectAnswer)
+	g.GameRoomState.CharSet = g.Dictionary.GeneratePrompt(g.GameRoomState.Round)
+	g.GameRoomState.Points[c.UserId] += breakdown.Total
+	g.GameRoomState.ScoreBreakdowns[c.UserId] = g.GameRoomState.ScoreBreakdowns[c.UserId].Add(breakdown)
+
+	g.broadcastAnswer(c.UserId, answer, true, "", &breakdown)
+	g.trackLetters(c.UserId, word)
+	g.handleSuccessfulAnswer(c.UserId, answer, g.GameRoomState.CharSet)
+}
+
+func (g *gameEngine) HandleTurnTimeout(turnID int) {
+	if !g.GameRoomState.Started || g.GameRoomState.TurnID != turnID {
+		return
+	}
+
+	uid := g.GameRoomState.Players[g.GameRoomState.TurnIndex]
+	g.loseLife(uid)
+	g.recordTurn(uid, "", model.ReasonTimeout)
+
+	g.Pool.BroadcastToRoom(g.GameRoomState.RoomID, g.turnEndedMessage(uid, model.ReasonTimeout))
+
+	g.StartNextTurn()
+}
+
+func (g *gameEngine) RemovePlayer(userID string) {
+	if !g.GameRoomState.Started {
+		for i, uid := range g.GameRoomState.Players {
+			if uid == userID {
+				g.GameRoomState.Players = append(g.GameRoomState.Players[:i], g.GameRoomState.Players[i+1:]...)
+				break
+			}
+		}
+		delete(g.GameRoomState.Lives, userID)
+		delete(g.GameRoomState.Points, userID)
+		delete(g.GameRoomState.PlayerNames, userID)
+		delete(g.GameRoomState.Teams, userID)
+		return
+	}
+
+	if g.GameRoomState.Lives[userID] == 0 {
+		return
+	}
+
+	if g.isTeamMode() {
+		g.removeFromTeam(userID)
+	} else {
+		g.GameRoomState.LivesLost[userID] += g.GameRoomState.Lives[userID]
+		g.GameRoomState.Lives[userID] = 0
+	}
+
+	if g.checkEndCondition() {
+		return
+	}
+
+	if g.GameRoomState.Players[g.GameRoomState.TurnIndex] == userID {
+		g.StartNextTurn()
+	}
+}
+
+func (g *gameEngine) Stop() {
+	if g.GameRoomState.TurnTimer != nil {
+		g.GameRoomState.TurnTimer.Stop()
+	}
+}
+
+func (g *gameEngine) recordTurn(userID string, answer string, result string) {
+	g.GameRoomState.Turns = append(g.GameRoomState.Turns, model.GameTurn{
+		TurnNumber:    len(g.GameRoomState.Turns) + 1,
+		Round:         g.GameRoomState.Round,
+		ParticipantID: userID,
+		CharSet:       g.GameRoomState.CharSet,
+		Answer:        answer,
+		Result:        result,
+	})
+}
+
+func (g *gameEngine) saveGame(event protocol.GameOverEvent) {
+	if g.Pool.games == nil {
+		event.RatingChanges = map[string]int{}
+		g.Pool.BroadcastToRoom(event.RoomID, protocol.NewMessage(event))
+		return
+	}
+
+	go g.Pool.recordGame(newGameRecord(g.GameRoomState), event)
+}
+
+func (g *gameEngine) validateAnswer(answer string) string {
+	if utf8.RuneCountInString(answer) < g.GameRoomState.Settings.MinWordLength {
+		return model.ReasonTooShort
+	}
+	if !util.ContainsSubstring(answer, g.GameRoomState.CharSet) {
+		return model.ReasonMissingCharSet
+	}
+	if !g.Dictionary.IsWordValid(answer) {
+		return model.ReasonInvalidWord
+	}
+	if g.GameRoomState.UsedWordSet[strings.ToLower(answer)] {
+		return model.ReasonAlreadyUsed
+	}
+	return ""
+}
+
+func (g *gameEngine) scoreAnswer(word string) model.ScoreBreakdown {
+	return g.Scorer.Score(ScoredAnswer{
+		Word:            word,
+		PromptSolutions: g.Dictionary.PromptSolutions(g.GameRoomState.CharSet),
+		TimeLimit:       time.Duration(g.GameRoomState.TimeLimit) * time.Second,
+		Remaining:       time.Until(g.GameRoomState.TurnEndsAt),
+		Rarity:          g.Dictionary.Rarity(word),
+	})
+}
+
+func (g *gameEngine) broadcastAnswer(userID string, answer string, correct bool, reason string, breakdown *model.ScoreBreakdown) {
+	message := protocol.NewMessage(protocol.AnswerEvent{
+		RoomID:         g.GameRoomState.RoomID,
+		UserID:         userID,
+		Answer:         answer,
+		Correct:        correct,
+		Reason:         reason,
+		ScoreBreakdown: breakdown,
+		CharSet:        g.GameRoomState.CharSet,
+		Score:          g.GameRoomState.Points[userID],
+		Lives:          g.GameRoomState.Lives[userID],
+	})
+
+	g.Pool.BroadcastToRoom(g.GameRoomState.RoomID, message)
+}
+
 func (g *gameEngine) countAlivePlayers() int {
 	count := 0
 	for _, life := range g.GameRoomState.Lives {
@@ -71,81 +289,110 @@ func (g *gameEngine) countAlivePlayers() int {
 	return count
 }
 
-func (g *gameEngine) startTurn(userID uint) {
-	g.GameRoomState.TimeLimit = max(g.RoundMaxTimeLimit-g.GameRoomState.Round, g.MinTimeLimit)
+func (g *gameEngine) startTurn(userID string) {
+	g.GameRoomState.TimeLimit = g.turnTimeLimit()
 
-	currentTurnIndex := g.GameRoomState.TurnIndex
-	newCharSet := util.GenerateRandomWord()
+	newCharSet := g.Dictionary.GeneratePrompt(g.GameRoomState.Round)
 	g.GameRoomState.CharSet = newCharSet
+	g.GameRoomState.TurnID++
+
+	lettersUsed, lettersRemaining := g.letterProgress(userID)
+	event := protocol.NextTurnEvent{
+		RoomID:           g.GameRoomState.RoomID,
+		UserID:           userID,
+		CharSet:          newCharSet,
+		TimeLimit:        g.GameRoomState.TimeLimit,
+		Round:            g.GameRoomState.Round,
+		Lives:            g.GameRoomState.Lives[userID],
+		LettersUsed:      lettersUsed,
+		LettersRemaining: lettersRemaining,
+	}
 
-	message := NewGameMessage().
-		SetMessageType(model.NextTurn).
-		WithRoomId(g.GameRoomState.RoomID).
-		WithUserId(userID).
-		WithCharSet(newCharSet).
-		WithTimeLimit(g.GameRoomState.TimeLimit).
-		WithRound(g.GameRoomState.Round).
-		WithLives(g.GameRoomState.Lives[userID]).
-		Build()
+	if g.isTeamMode() {
+		team := g.GameRoomState.Teams[userID]
+		event.Team = &team
+	}
 
-	g.Pool.BroadcastToRoom(g.GameRoomState.RoomID, message)
+	g.Pool.BroadcastToRoom(g.GameRoomState.RoomID, protocol.NewMessage(event))
 
-	go func(uid uint, timeLimit int, turnIndex int) {
-		timer := time.NewTimer(time.Duration(timeLimit) * time.Second)
-		defer timer.Stop()
+	g.Stop()
+	turnID := g.GameRoomState.TurnID
+	g.GameRoomState.TurnEndsAt = time.Now().Add(time.Duration(g.GameRoomState.TimeLimit) * time.Second)
+	g.GameRoomState.TurnTimer = time.AfterFunc(time.Duration(g.GameRoomState.TimeLimit)*time.Second, func() {
+		g.Room.post(gameEvent{eventType: turnTimeoutEvent, turnID: turnID})
+	})
+}
 
-		<-timer.C
+func (g *gameEngine) trackLetters(userID string, word string) {
+	used := g.GameRoomState.LettersUsed[userID]
+	if used == nil {
+		used = make(map[string]bool)
+		g.GameRoomState.LettersUsed[userID] = used
+	}
+	for _, r := range word {
+		used[string(r)] = true
+	}
 
-		if g.GameRoomState.TurnIndex == turnIndex &&
-			g.GameRoomState.Players[g.GameRoomState.TurnIndex] == uid {
+	for _, letter := range g.Dictionary.Alphabet() {
+		if !used[letter] {
+			return
+		}
+	}
 
-			g.GameRoomState.Lives[uid]--
+	g.GameRoomState.LettersUsed[userID] = make(map[string]bool)
+	if !g.gainLife(userID) {
+		return
+	}
 
-			message := NewGameMessage().
-				SetMessageType(model.TurnEnded).
-				WithRoomId(g.GameRoomState.RoomID).
-				WithUserId(uid).
-				WithReason("timeout").
-				WithLives(g.GameRoomState.Lives[uid]).
-				WithRound(g.GameRoomState.Round).
-				WithScore(g.GameRoomState.Points[uid]).
-				Build()
+	message := protocol.NewMessage(protocol.LifeGainedEvent{
+		RoomID: g.GameRoomState.RoomID,
+		UserID: userID,
+		Lives:  g.GameRoomState.Lives[userID],
+	})
 
-			g.Pool.BroadcastToRoom(g.GameRoomState.RoomID, message)
+	g.Pool.BroadcastToRoom(g.GameRoomState.RoomID, message)
+}
 
-			g.StartNextTurn()
+func (g *gameEngine) letterProgress(userID string) ([]string, []string) {
+	used := make([]string, 0)
+	remaining := make([]string, 0)
+	for _, letter := range g.Dictionary.Alphabet() {
+		if g.GameRoomState.LettersUsed[userID][letter] {
+			used = append(used, letter)
+		} else {
+			remaining = append(remaining, letter)
 		}
-	}(userID, g.GameRoomState.TimeLimit, currentTurnIndex)
+	}
+	return used, remaining
 }
 
-func (g *gameEngine) handleSuccessfulAnswer(userID uint, answer string, newCharSet string) {
-	message := NewGameMessage().
-		SetMessageType(model.TurnEnded).
-		WithRoomId(g.GameRoomState.RoomID).
-		WithUserId(userID).
-		WithReason("correct_answer").
-		WithLives(g.GameRoomState.Lives[userID]).
-		WithRound(g.GameRoomState.Round).
-		WithScore(g.GameRoomState.Points[userID]).
-		Build()
+func (g *gameEngine) turnTimeLimit() int {
+	settings := g.GameRoomState.Settings
+	return max(settings.MaxTurnTime-g.GameRoomState.Round*settings.TurnTimeDecay, settings.MinTurnTime)
+}
 
-	g.Pool.BroadcastToRoom(g.GameRoomState.RoomID, message)
+func (g *gameEngine) handleSuccessfulAnswer(userID string, answer string, newCharSet string) {
+	g.Pool.BroadcastToRoom(g.GameRoomState.RoomID, g.turnEndedMessage(userID, model.ReasonCorrectAnswer))
 
 	g.StartNextTurn()
 }
 
-func (g *gameEngine) handleWrongAnswer(userID uint, answer string) {
-	message := NewGameMessage().
-		SetMessageType(model.TurnEnded).
-		WithRoomId(g.GameRoomState.RoomID).
-		WithUserId(userID).
-		WithReason("wrong_answer").
-		WithLives(g.GameRoomState.Lives[userID]).
-		WithRound(g.GameRoomState.Round).
-		WithScore(g.GameRoomState.Points[userID]).
-		Build()
+func (g *gameEngine) turnEndedMessage(userID string, reason string) model.GameMessage {
+	lettersUsed, lettersRemaining := g.letterProgress(userID)
+	return protocol.NewMessage(protocol.TurnEndedEvent{
+		RoomID:           g.GameRoomState.RoomID,
+		UserID:           userID,
+		Reason:           reason,
+		Lives:            g.GameRoomState.Lives[userID],
+		Round:            g.GameRoomState.Round,
+		Score:            g.GameRoomState.Points[userID],
+		LettersUsed:      lettersUsed,
+		LettersRemaining: lettersRemaining,
+	})
+}
 
-	g.Pool.BroadcastToRoom(g.GameRoomState.RoomID, message)
+func (g *gameEngine) handleWrongAnswer(userID string, answer string) {
+	g.Pool.BroadcastToRoom(g.GameRoomState.RoomID, g.turnEndedMessage(userID, model.ReasonWrongAnswer))
 
 	if g.checkEndCondition() {
 		return
@@ -157,24 +404,50 @@ func (g *gameEngine) handleWrongAnswer(userID uint, answer string) {
 	}
 }
 
-func (g *gameEngine) endGame(winnerID uint) {
+func (g *gameEngine) endGame(winnerID string) {
+	g.Stop()
 	g.GameRoomState.Started = false
+	g.GameRoomState.Finished = true
 	g.GameRoomState.WinnerID = winnerID
+	g.recordSeriesResult()
+
+	event := protocol.GameOverEvent{
+		RoomID:      g.GameRoomState.RoomID,
+		WinnerID:    winnerID,
+		FinalScores: g.getFinalScores(),
+		UsedWords:   slices.Clone(g.GameRoomState.UsedWords),
+		SeriesGames: g.GameRoomState.SeriesGames,
+		SeriesWins:  maps.Clone(g.GameRoomState.SeriesWins),
+	}
 
-	message := NewGameMessage().
-		SetMessageType(model.GameOver).
-		WithRoomId(g.GameRoomState.RoomID).
-		WithWinnerId(winnerID).
-		WithFinalScores(g.getFinalScores()).
-		Build()
+	if g.isTeamMode() {
+		winningTeam := g.GameRoomState.WinningTeam
+		event.WinningTeam = &winningTeam
+		event.TeamResults = g.teamResults()
+	}
 
-	g.Pool.BroadcastToRoom(g.GameRoomState.RoomID, message)
+	g.saveGame(event)
+}
+
+func (g *gameEngine) recordSeriesResult() {
+	state := g.GameRoomState
+	state.SeriesGames++
+	for _, uid := range state.Players {
+		team, inTeam := state.Teams[uid]
+		if uid == state.WinnerID || (g.isTeamMode() && inTeam && team == state.WinningTeam) {
+			state.SeriesWins[uid]++
+		}
+	}
 }
 
 func (g *gameEngine) checkEndCondition() bool {
+	if g.isTeamMode() {
+		return g.checkTeamEndCondition()
+	}
+
 	aliveCount := 0
-	var lastAlivePlayer uint
-	var highestScorePlayer uint
+	var lastAlivePlayer string
+	var highestScorePlayer string
 	maxScore := MaxScoreForComparison
 
 	for uid, life := range g.GameRoomState.Lives {
@@ -190,7 +463,7 @@ func (g *gameEngine) checkEndCondition() bool {
 	}
 
 	if aliveCount <= MinAlivePlayersForGameEnd {
-		var winnerID uint
+		var winnerID string
 		if aliveCount == 1 {
 			winnerID = lastAlivePlayer
 		} else {
@@ -202,13 +475,18 @@ func (g *gameEngine) checkEndCondition() bool {
 	return false
 }
 
-func (g *gameEngine) getFinalScores() map[string]any {
-	scores := make(map[string]any)
+func (g *gameEngine) getFinalScores() map[string]protocol.FinalScore {
+	scores := make(map[string]protocol.FinalScore)
 	for uid, points := range g.GameRoomState.Points {
-		scores[fmt.Sprintf("user_%d", uid)] = map[string]any{
-			"points": points,
-			"lives":  g.GameRoomState.Lives[uid],
+		score := protocol.FinalScore{
+			Points:    points,
+			Lives:     g.GameRoomState.Lives[uid],
+			Breakdown: g.GameRoomState.ScoreBreakdowns[uid],
+		}
+		if team, exists := g.GameRoomState.Teams[uid]; exists && g.isTeamMode() {
+			score.Team = &team
 		}
+		scores[uid] = score
 	}
 	return scores
 }
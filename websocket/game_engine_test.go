@@ -0,0 +1,185 @@
+package websocket
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lakshya1goel/Playzio/bootstrap/dictionary"
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"gorm.io/gorm"
+)
+
+func TestAnswerOutOfTurnIsRejected(t *testing.T) {
+	pool, server := newTestPool(t)
+	_, clients := joinTestRoom(t, pool, server, 1, "user:1", "guest:a")
+	current, charSet := startTestGame(t, clients)
+	other := otherClient(clients, current)
+
+	other.send(t, model.Answer, map[string]any{"answer": validWord(t, pool, charSet)})
+
+	errMsg := other.expect(t, model.Error, nil)
+	if errMsg.Payload["code"] != model.ErrorNotYourTurn {
+		t.Fatalf("expected %q, got %v", model.ErrorNotYourTurn, errMsg.Payload["code"])
+	}
+	current.expectNone(t, model.Error, 200*time.Millisecond)
+
+	word := validWord(t, pool, charSet)
+	current.send(t, model.Answer, map[string]any{"answer": word})
+
+	answer := other.expect(t, model.Answer, nil)
+	if payloadString(answer, "user_id") != current.userID {
+		t.Fatalf("out-of-turn answer was broadcast for user %s", payloadString(answer, "user_id"))
+	}
+	breakdown, _ := answer.Payload["score_breakdown"].(map[string]any)
+	if answer.Payload["correct"] != true || payloadUint(answer, "score") == 0 || breakdown["total"] != answer.Payload["score"] {
+		t.Fatalf("expected correct answer scored by its breakdown, got %v", answer.Payload)
+	}
+}
+
+func TestLateAnswerAfterTimeoutIsRejected(t *testing.T) {
+	settings := model.DefaultGameSettings()
+	settings.MaxTurnTime = 1
+	settings.MinTurnTime = 1
+	pool, server := newTestPool(t, model.Room{Model: gorm.Model{ID: 2}, Settings: settings})
+	_, clients := joinTestRoom(t, pool, server, 2, "user:1", "guest:a")
+
+	current, charSet := startTestGame(t, clients)
+	other := otherClient(clients, current)
+
+	ended := other.expect(t, model.TurnEnded, fromUser(current.userID))
+	if ended.Payload["reason"] != model.ReasonTimeout {
+		t.Fatalf("expected timeout, got %v", ended.Payload["reason"])
+	}
+	current.expect(t, model.NextTurn, fromUser(other.userID))
+
+	current.send(t, model.Answer, map[string]any{"answer": validWord(t, pool, charSet)})
+
+	errMsg := current.expect(t, model.Error, nil)
+	if errMsg.Payload["code"] != model.ErrorNotYourTurn {
+		t.Fatalf("expected %q, got %v", model.ErrorNotYourTurn, errMsg.Payload["code"])
+	}
+	other.expectNone(t, model.Answer, 200*time.Millisecond)
+}
+
+func TestDuplicateSubmissionIsRejected(t *testing.T) {
+	settings := model.DefaultGameSettings()
+	settings.Scoring = model.ScoringFlat
+	pool, server := newTestPool(t, model.Room{Model: gorm.Model{ID: 3}, Settings: settings})
+	_, clients := joinTestRoom(t, pool, server, 3, "user:1", "guest:a")
+	current, charSet := startTestGame(t, clients)
+	other := otherClient(clients, current)
+
+	word := validWord(t, pool, charSet)
+	current.send(t, model.Answer, map[string]any{"answer": word})
+	current.send(t, model.Answer, map[string]any{"answer": word})
+
+	ended := other.expect(t, model.TurnEnded, fromUser(current.userID))
+	if ended.Payload["reason"] != model.ReasonCorrectAnswer || payloadUint(ended, "score") != 1 {
+		t.Fatalf("expected one correct answer, got %v", ended.Payload)
+	}
+
+	errMsg := current.expect(t, model.Error, nil)
+	if errMsg.Payload["code"] != model.ErrorNotYourTurn {
+		t.Fatalf("expected %q, got %v", model.ErrorNotYourTurn, errMsg.Payload["code"])
+	}
+	other.expectNone(t, model.Error, 200*time.Millisecond)
+}
+
+type fixedPromptDictionary struct {
+	dictionary.Dictionary
+	prompt string
+}
+
+func (d fixedPromptDictionary) GeneratePrompt(round int) string {
+	return d.prompt
+}
+
+func TestRepeatedWordIsRejectedAndListedAtGameOver(t *testing.T) {
+	settings := model.DefaultGameSettings()
+	settings.Lives = 1
+	settings.LifeCap = 1
+	settings.MaxTurnTime = 1
+	settings.MinTurnTime = 1
+	pool, server := newTestPool(t, model.Room{Model: gorm.Model{ID: 16}, Settings: settings})
+	pool.dictionaries.Register(fixedPromptDictionary{Dictionary: pool.Dictionary(dictionary.DefaultLanguage), prompt: "a"})
+	_, clients := joinTestRoom(t, pool, server, 16, "user:1", "guest:a")
+
+	current, charSet := startTestGame(t, clients)
+	other := otherClient(clients, current)
+	word := validWord(t, pool, charSet)
+	current.send(t, model.Answer, map[string]any{"answer": word})
+	other.expect(t, model.NextTurn, fromUser(other.userID))
+
+	other.send(t, model.Answer, map[string]any{"answer": word})
+	answer := current.expect(t, model.Answer, fromUser(other.userID))
+	if answer.Payload["correct"] != false || answer.Payload["reason"] != model.ReasonAlreadyUsed {
+		t.Fatalf("expected answer rejected as %q, got %v", model.ReasonAlreadyUsed, answer.Payload)
+	}
+
+	gameOver := current.expect(t, model.GameOver, nil)
+	usedWords, _ := gameOver.Payload["used_words"].([]any)
+	if !slices.Equal(usedWords, []any{word}) {
+		t.Fatalf("expected used words [%s], got %v", word, gameOver.Payload["used_words"])
+	}
+}
+
+func TestRoomSettingsApplyToGame(t *testing.T) {
+	settings := model.DefaultGameSettings()
+	settings.Lives = 5
+	settings.MinWordLength = model.MaxMinWordLength
+	pool, server := newTestPool(t, model.Room{Model: gorm.Model{ID: 4}, Settings: settings})
+	_, clients := joinTestRoom(t, pool, server, 4, "user:1", "guest:a")
+
+	clients[0].send(t, model.StartGame, map[string]any{"duration": 0})
+	turn := clients[0].expect(t, model.NextTurn, nil)
+	if lives := payloadUint(turn, "lives"); lives != 5 {
+		t.Fatalf("expected 5 lives, got %d", lives)
+	}
+	if timeLimit := payloadUint(turn, "time_limit"); timeLimit != model.DefaultMaxTurnTime-1 {
+		t.Fatalf("expected a %d second turn, got %d", model.DefaultMaxTurnTime-1, timeLimit)
+	}
+
+	current := clients[0]
+	if payloadString(turn, "user_id") != current.userID {
+		current = clients[1]
+	}
+	current.send(t, model.Answer, map[string]any{"answer": validWord(t, pool, payloadString(turn, "char_set"))})
+
+	answer := current.expect(t, model.Answer, nil)
+	if answer.Payload["correct"] != false || answer.Payload["reason"] != model.ReasonTooShort {
+		t.Fatalf("expected answer rejected as %q, got %v", model.ReasonTooShort, answer.Payload)
+	}
+}
+
+func TestCompletingTheAlphabetGrantsALifeUpToTheCap(t *testing.T) {
+	pool, _ := newTestPool(t)
+	settings := model.DefaultGameSettings()
+	settings.LifeCap = settings.Lives + 1
+	room := NewGameRoom(pool, &model.GameRoomState{
+		RoomID:      99,
+		Language:    "en",
+		Settings:    settings,
+		Lives:       map[string]int{"user:1": settings.Lives},
+		LettersUsed: make(map[string]map[string]bool),
+	})
+	engine := room.engine.(*gameEngine)
+
+	engine.trackLetters("user:1", "abcdefghijklm")
+	if used, remaining := engine.letterProgress("user:1"); len(used) != 13 || len(remaining) != 13 {
+		t.Fatalf("expected 13 letters used and 13 remaining, got %v and %v", used, remaining)
+	}
+
+	engine.trackLetters("user:1", "nopqrstuvwxyz")
+	if lives := room.State.Lives["user:1"]; lives != settings.LifeCap {
+		t.Fatalf("expected %d lives after completing the alphabet, got %d", settings.LifeCap, lives)
+	}
+	if used, _ := engine.letterProgress("user:1"); len(used) != 0 {
+		t.Fatalf("expected letter progress to reset, got %v", used)
+	}
+
+	engine.trackLetters("user:1", "abcdefghijklmnopqrstuvwxyz")
+	if lives := room.State.Lives["user:1"]; lives != settings.LifeCap {
+		t.Fatalf("expected lives to stay capped at %d, got %d", settings.LifeCap, lives)
+	}
+}
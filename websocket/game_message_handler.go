@@ -1,17 +1,24 @@
 package websocket
 
 import (
-	"fmt"
+	"time"
 
-	"github.com/lakshya1goel/Playzio/bootstrap/util"
 	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
 )
 
 type GameMessageHandler interface {
-	HandleJoin(client *GameClient, message model.GameMessage) bool
-	HandleAnswer(client *GameClient, message model.GameMessage) bool
-	HandleTyping(client *GameClient, message model.GameMessage) bool
-	ExtractRoomID(message model.GameMessage) (uint, error)
+	HandleJoin(client *GameClient, request *protocol.JoinRequest) bool
+	HandleAnswer(client *GameClient, request *protocol.AnswerRequest) bool
+	HandleResync(client *GameClient, request *protocol.ResyncRequest) bool
+	HandleStartGame(client *GameClient, request *protocol.StartGameRequest) bool
+	HandleCancelCountdown(client *GameClient, request *protocol.CancelCountdownRequest) bool
+	HandleExtendCountdown(client *GameClient, request *protocol.ExtendCountdownRequest) bool
+	HandleJoinTeam(client *GameClient, request *protocol.JoinTeamRequest) bool
+	HandleAddBot(client *GameClient, request *protocol.AddBotRequest) bool
+	HandleRemoveBot(client *GameClient, request *protocol.RemoveBotRequest) bool
+	HandleRematch(client *GameClient, request *protocol.RematchRequest) bool
+	HandleTyping(client *GameClient, request *protocol.TypingRequest) bool
 }
 
 type gameMessageHandler struct {
@@ -24,107 +31,148 @@ func NewGameMessageHandler(pool *GamePool) GameMessageHandler {
 	}
 }
 
-func (h *gameMessageHandler) HandleJoin(c *GameClient, msg model.GameMessage) bool {
-	roomID, err := h.ExtractRoomID(msg)
-	if err != nil {
-		fmt.Println("Invalid room ID:", err)
+func (h *gameMessageHandler) HandleJoin(c *GameClient, request *protocol.JoinRequest) bool {
+	c.ResumeToken = request.ResumeToken
+	c.Spectator = request.Role == model.RoleSpectator
+
+	h.pool.JoinRoom(c, request.RoomID)
+	return true
+}
+
+func (h *gameMessageHandler) HandleResync(c *GameClient, request *protocol.ResyncRequest) bool {
+	room := h.joinedRoom(c)
+	if room == nil {
+		return false
+	}
+
+	room.RequestResync(c)
+	return true
+}
+
+func (h *gameMessageHandler) HandleAnswer(c *GameClient, request *protocol.AnswerRequest) bool {
+	room := h.joinedRoom(c)
+	if room == nil {
+		return false
+	}
+
+	room.SubmitAnswer(c, request.Answer)
+	return true
+}
+
+func (h *gameMessageHandler) HandleStartGame(c *GameClient, request *protocol.StartGameRequest) bool {
+	room := h.joinedRoom(c)
+	if room == nil {
+		return false
+	}
+
+	room.RequestStart(c, h.duration(request.Duration, DefaultCountdownDuration))
+	return true
+}
+
+func (h *gameMessageHandler) HandleCancelCountdown(c *GameClient, request *protocol.CancelCountdownRequest) bool {
+	room := h.joinedRoom(c)
+	if room == nil {
+		return false
+	}
+
+	room.CancelCountdown(c)
+	return true
+}
+
+func (h *gameMessageHandler) HandleExtendCountdown(c *GameClient, request *protocol.ExtendCountdownRequest) bool {
+	room := h.joinedRoom(c)
+	if room == nil {
 		return false
 	}
 
-	h.pool.JoinRoom(c, roomID)
+	room.ExtendCountdown(c, h.duration(request.Duration, ExtendCountdownDuration))
 	return true
 }
 
-func (h *gameMessageHandler) HandleAnswer(c *GameClient, msg model.GameMessage) bool {
-	gameRoomState := h.pool.gameStateManager.GetRoomState(c.RoomID)
-	if gameRoomState == nil || !gameRoomState.Started {
-		fmt.Println("Room not found or game not started")
+func (h *gameMessageHandler) HandleJoinTeam(c *GameClient, request *protocol.JoinTeamRequest) bool {
+	room := h.joinedRoom(c)
+	if room == nil {
 		return false
 	}
 
-	answer, ok := msg.Payload["answer"].(string)
-	if !ok {
-		fmt.Println("Answer is not a string")
+	room.ChooseTeam(c, *request.Team)
+	return true
+}
+
+func (h *gameMessageHandler) HandleAddBot(c *GameClient, request *protocol.AddBotRequest) bool {
+	room := h.joinedRoom(c)
+	if room == nil {
+		return false
+	}
+
+	difficulty := request.Difficulty
+	if difficulty == "" {
+		difficulty = DefaultBotDifficulty
+	}
+
+	room.AddBot(c, difficulty)
+	return true
+}
+
+func (h *gameMessageHandler) HandleRemoveBot(c *GameClient, request *protocol.RemoveBotRequest) bool {
+	room := h.joinedRoom(c)
+	if room == nil {
 		return false
 	}
 
-	game := NewGameEngine(h.pool, gameRoomState)
+	room.RemoveBot(c, request.UserID)
+	return true
+}
 
-	if util.ContainsSubstring(answer, gameRoomState.CharSet) && util.IsWordValid(answer) {
-		h.handleCorrectAnswer(c, gameRoomState, answer, game)
-	} else {
-		h.handleWrongAnswer(c, gameRoomState, answer, game)
+func (h *gameMessageHandler) HandleRematch(c *GameClient, request *protocol.RematchRequest) bool {
+	room := h.joinedRoom(c)
+	if room == nil {
+		return false
 	}
 
+	room.RequestRematch(c)
 	return true
 }
 
-func (h *gameMessageHandler) HandleTyping(c *GameClient, msg model.GameMessage) bool {
-	text, ok := msg.Payload["text"].(string)
-	if !ok {
+func (h *gameMessageHandler) HandleTyping(c *GameClient, request *protocol.TypingRequest) bool {
+	if c.Spectator {
+		h.pool.SendError(c, model.ErrorSpectator, "Spectators cannot type")
+		return false
+	}
+	if h.joinedRoom(c) == nil {
+		return false
+	}
+	if h.pool.IsMuted(c.RoomID, c.UserId) {
+		h.pool.SendError(c, model.ErrorMuted, "You are muted in this room")
 		return false
 	}
 
-	message := NewGameMessage().
-		SetMessageType(model.Typing).
-		WithRoomId(c.RoomID).
-		WithUserId(c.UserId).
-		WithText(text).
-		Build()
+	message := protocol.NewMessage(protocol.TypingEvent{
+		RoomID: c.RoomID,
+		UserID: c.UserId,
+		Text:   request.Text,
+	})
 
-	h.pool.BroadcastMessage(c, message)
+	h.pool.BroadcastToRoom(c.RoomID, message)
 	return true
 }
 
-func (h *gameMessageHandler) ExtractRoomID(msg model.GameMessage) (uint, error) {
-	roomIDRaw, exists := msg.Payload["room_id"]
-	if !exists {
-		return 0, fmt.Errorf("room ID not found in payload")
-	}
-
-	switch v := roomIDRaw.(type) {
-	case float64:
-		return uint(v), nil
-	case uint:
-		return v, nil
-	case int:
-		return uint(v), nil
-	default:
-		return 0, fmt.Errorf("invalid room ID type: %T", v)
-	}
-}
-
-func (h *gameMessageHandler) handleCorrectAnswer(c *GameClient, state *model.GameRoomState, answer string, game GameEngine) {
-	state.CharSet = util.GenerateRandomWord()
-	state.Points[c.UserId]++
-
-	message := NewGameMessage().
-		SetMessageType(model.Answer).
-		WithRoomId(c.RoomID).
-		WithUserId(c.UserId).
-		WithCorrect(true).
-		WithAnswer(answer).
-		WithCharSet(state.CharSet).
-		WithScore(state.Points[c.UserId]).
-		WithLives(state.Lives[c.UserId]).
-		Build()
-
-	h.pool.BroadcastMessage(c, message)
-	game.handleSuccessfulAnswer(c.UserId, answer, state.CharSet)
-}
-
-func (h *gameMessageHandler) handleWrongAnswer(c *GameClient, state *model.GameRoomState, answer string, game GameEngine) {
-	message := NewGameMessage().
-		SetMessageType(model.Answer).
-		WithRoomId(c.RoomID).
-		WithUserId(c.UserId).
-		WithCorrect(false).
-		WithAnswer(answer).
-		WithCharSet(state.CharSet).
-		WithScore(state.Points[c.UserId]).
-		WithLives(state.Lives[c.UserId]).
-		Build()
-
-	h.pool.BroadcastMessage(c, message)
-	game.handleWrongAnswer(c.UserId, answer)
+func (h *gameMessageHandler) joinedRoom(c *GameClient) *GameRoom {
+	if !h.pool.IsMember(c) {
+		h.pool.SendError(c, model.ErrorNotJoined, "Join a room first")
+		return nil
+	}
+
+	room := h.pool.gameStateManager.GetRoom(c.RoomID)
+	if room == nil {
+		h.pool.SendError(c, model.ErrorNotJoined, "Join a room first")
+	}
+	return room
+}
+
+func (h *gameMessageHandler) duration(seconds *int, fallback time.Duration) time.Duration {
+	if seconds == nil {
+		return fallback
+	}
+	return time.Duration(*seconds) * time.Second
 }
@@ -1,229 +1,479 @@
 package websocket
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lakshya1goel/Playzio/bootstrap/dictionary"
 	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain"
 	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
 )
 
+type RoomProvider interface {
+	GetRoomByID(c *gin.Context, id uint) (model.Room, error)
+}
+
+type GameRecorder interface {
+	RecordGame(ctx context.Context, game *model.Game) (map[string]int, *domain.HttpError)
+}
+
+type joinRequest struct {
+	client   *GameClient
+	roomID   uint
+	room     *model.Room
+	accepted chan bool
+}
+
 type GamePool struct {
 	*BasePool[*GameClient]
 	gameStateManager   GameStateManager
 	gameTimerManager   GameTimerManager
 	gameMessageHandler GameMessageHandler
+	dictionaries       *dictionary.Registry
+	rooms              RoomProvider
+	games              GameRecorder
+	Disconnect         chan *GameClient
+	joins              chan joinRequest
+	roomIdle           chan *GameRoom
+	roomLost           chan uint
+	gracePeriod        time.Duration
+	leaseTTL           time.Duration
+	botDifficulties    map[string]BotDifficulty
+	bus                RoomBus
+	nodeID             string
+	relayed            map[uint]map[string]*GameClient
+	proxies            map[string]*GameClient
+	muted              map[uint]map[string]bool
 }
 
-func NewGamePool() *GamePool {
+func NewGamePool(dictionaries *dictionary.Registry, rooms RoomProvider, games GameRecorder, bus RoomBus) *GamePool {
 	pool := &GamePool{
-		BasePool: NewBasePool[*GameClient](),
+		BasePool:        NewBasePool[*GameClient](),
+		dictionaries:    dictionaries,
+		rooms:           rooms,
+		games:           games,
+		Disconnect:      make(chan *GameClient),
+		joins:           make(chan joinRequest),
+		roomIdle:        make(chan *GameRoom),
+		roomLost:        make(chan uint),
+		gracePeriod:     ReconnectGracePeriod,
+		leaseTTL:        RoomLeaseTTL,
+		botDifficulties: BotDifficulties,
+		bus:             bus,
+		nodeID:          uuid.NewString(),
+		relayed:         make(map[uint]map[string]*GameClient),
+		proxies:         make(map[string]*GameClient),
+		muted:           make(map[uint]map[string]bool),
 	}
-	pool.gameStateManager = NewGameStateManager()
+	pool.gameStateManager = NewGameStateManager(pool)
 	pool.gameTimerManager = NewGameTimerManager(pool)
 	pool.gameMessageHandler = NewGameMessageHandler(pool)
 	return pool
 }
 
 func (p *GamePool) Start() {
+	p.startBus()
+
 	for {
 		select {
-		case client := <-p.Register:
-			p.handleClientRegister(client)
+		case request := <-p.joins:
+			p.handleJoinRequest(request)
 		case client := <-p.Unregister:
 			p.handleClientUnregister(client)
-		case raw := <-p.Broadcast:
-			if !p.handleBroadcast(raw) {
-				continue
-			}
+		case client := <-p.Disconnect:
+			p.handleClientDisconnect(client)
+		case room := <-p.roomIdle:
+			p.handleRoomIdle(room)
+		case roomID := <-p.roomLost:
+			p.handleRoomLost(roomID)
 		}
 	}
 }
 
-func (p *GamePool) handleClientRegister(client *GameClient) {
-	util.RegisterClient(&p.mu, p.Rooms, client.RoomID, client.UserId, client)
-
-	roomState := p.gameStateManager.GetRoomState(client.RoomID)
-	if roomState == nil {
-		p.gameStateManager.CreateRoomState(client.RoomID, client.UserId)
-		p.gameTimerManager.StartCountdown(client.RoomID, CountdownDuration)
-	} else {
-		if p.gameStateManager.AddPlayer(client.RoomID, client.UserId) {
-			remainingTime := p.gameTimerManager.GetRemainingCountdownTime(client.RoomID)
-			if remainingTime > 0 {
-				p.BroadcastTimerStarted(client.RoomID, remainingTime)
-			}
+func (p *GamePool) handleJoinRequest(request joinRequest) {
+	room := p.gameStateManager.GetRoom(request.roomID)
+	if room == nil {
+		language, host, settings := p.roomDetails(request.room)
+		if host == "" && !request.client.Spectator {
+			host = request.client.UserId
 		}
+		room = p.gameStateManager.CreateRoom(request.roomID, host, language, settings)
 	}
+	room.Join(request.client, request.accepted)
+}
 
-	message := NewGameMessage().
-		SetMessageType(model.UserJoined).
-		WithUserId(client.UserId).
-		WithRoomId(client.RoomID).
-		WithUserName(client.UserName).
-		Build()
+func (p *GamePool) handleClientUnregister(client *GameClient) {
+	roomID := client.RoomID
+	if !p.removeMember(roomID, client) {
+		return
+	}
+	p.forgetProxy(client)
 
-	p.BroadcastToRoom(client.RoomID, message)
+	room := p.gameStateManager.GetRoom(roomID)
+	if room != nil {
+		room.Leave(client)
+	}
 }
 
-func (p *GamePool) handleClientUnregister(client *GameClient) {
-	message := NewGameMessage().
-		SetMessageType(model.UserLeft).
-		WithUserId(client.UserId).
-		WithRoomId(client.RoomID).
-		WithUserName(client.UserName).
-		Build()
+func (p *GamePool) handleClientDisconnect(client *GameClient) {
+	if p.dropRelayed(client) {
+		p.relayDisconnect(client)
+		return
+	}
+	roomID := client.RoomID
+	if !p.removeMember(roomID, client) {
+		return
+	}
+	p.forgetProxy(client)
 
-	p.BroadcastToRoom(client.RoomID, message)
+	room := p.gameStateManager.GetRoom(roomID)
+	if room != nil {
+		room.Disconnect(client)
+	}
+}
+
+func (p *GamePool) handleRoomIdle(room *GameRoom) {
+	roomID := room.State.RoomID
+	if p.gameStateManager.GetRoom(roomID) != room || room.joining.Load() > 0 {
+		return
+	}
+	members := p.members(roomID)
+	for _, client := range members {
+		if !client.IsBot {
+			return
+		}
+	}
+	for _, client := range members {
+		p.removeMember(roomID, client)
+		p.forgetProxy(client)
+	}
+	p.gameStateManager.RemoveRoom(roomID)
+	p.releaseRoom(roomID)
 
-	util.UnregisterClient(&p.mu, p.Rooms, client.RoomID, client.UserId)
+	p.mu.Lock()
+	delete(p.muted, roomID)
+	p.mu.Unlock()
+}
 
-	if len(p.Rooms[client.RoomID]) == 0 {
-		p.gameTimerManager.StopCountdown(client.RoomID)
-		p.gameStateManager.RemoveRoom(client.RoomID)
+func (p *GamePool) handleRoomLost(roomID uint) {
+	if p.gameStateManager.GetRoom(roomID) == nil {
+		return
+	}
+	for _, client := range p.members(roomID) {
+		if !client.IsBot {
+			p.SendError(client, model.ErrorRoomMoved, "The room moved to another server, join again")
+		}
+		p.removeMember(roomID, client)
+		p.forgetProxy(client)
 	}
+	p.gameStateManager.RemoveRoom(roomID)
+
+	p.mu.Lock()
+	delete(p.muted, roomID)
+	p.mu.Unlock()
+}
+
+func (p *GamePool) addMember(roomID uint, c *GameClient) {
+	util.RegisterClient(&p.mu, p.Rooms, roomID, c.UserId, c)
 }
 
-func (p *GamePool) handleBroadcast(raw interface{}) bool {
-	msg, ok := raw.(model.GameMessage)
-	if !ok {
+func (p *GamePool) removeMember(roomID uint, c *GameClient) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Rooms[roomID][c.UserId] != c {
 		return false
 	}
+	delete(p.Rooms[roomID], c.UserId)
+	if len(p.Rooms[roomID]) == 0 {
+		delete(p.Rooms, roomID)
+	}
+	return true
+}
+
+func (p *GamePool) members(roomID uint) map[string]*GameClient {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return maps.Clone(p.Rooms[roomID])
+}
+
+func (p *GamePool) loadRoom(roomID uint) *model.Room {
+	if p.rooms == nil {
+		return nil
+	}
 
-	roomID, err := p.gameMessageHandler.ExtractRoomID(msg)
+	room, err := p.rooms.GetRoomByID(nil, roomID)
 	if err != nil {
-		return false
+		return nil
 	}
+	return &room
+}
 
-	p.mu.RLock()
-	clients := p.Rooms[roomID]
-	for _, client := range clients {
-		go client.WriteJSON(msg)
+func (p *GamePool) roomDetails(room *model.Room) (string, string, model.GameSettings) {
+	if room == nil {
+		return p.dictionaries.DefaultLanguage(), "", model.DefaultGameSettings()
 	}
-	p.mu.RUnlock()
-	return true
+
+	language := room.Language
+	if !p.dictionaries.Has(language) {
+		language = p.dictionaries.DefaultLanguage()
+	}
+	return language, util.MemberParticipantID(room.CreatedBy, room.CreatorGuestID), room.Settings
+}
+
+func (p *GamePool) roomSettings(roomID uint) (model.GameSettings, bool) {
+	room := p.loadRoom(roomID)
+	if room == nil {
+		return model.GameSettings{}, false
+	}
+	return room.Settings, true
+}
+
+func memberStatus(room *model.Room, userID string) (bool, bool) {
+	if room == nil {
+		return false, false
+	}
+
+	for _, member := range room.Members {
+		if util.MemberParticipantID(member.UserID, member.GuestID) == userID {
+			return member.IsKicked, member.IsMuted
+		}
+	}
+	return false, false
+}
+
+func (p *GamePool) recordGame(game *model.Game, event protocol.GameOverEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), GameSaveTimeout)
+	defer cancel()
+
+	event.RatingChanges = map[string]int{}
+	ratingChanges, err := p.games.RecordGame(ctx, game)
+	if err != nil {
+		fmt.Println("Error saving game result:", err.Message)
+	} else {
+		event.GameID = game.ID
+		event.RatingChanges = ratingChanges
+	}
+	p.BroadcastToRoom(event.RoomID, protocol.NewMessage(event))
+}
+
+func (p *GamePool) Dictionary(language string) dictionary.Dictionary {
+	return p.dictionaries.Get(language)
 }
 
 func (p *GamePool) Read(c *GameClient) {
 	defer func() {
 		c.StopPingPong()
-		p.LeaveRoom(c)
+		p.DisconnectClient(c)
 		c.Conn.Close()
 	}()
 
 	c.StartPingPong()
 
-	for {
-		var msg model.GameMessage
-		err := c.Conn.ReadJSON(&msg)
+	welcome := protocol.NewMessage(protocol.WelcomeEvent{
+		UserID:            c.UserId,
+		ProtocolVersion:   c.ProtocolVersion,
+		SupportedVersions: protocol.SupportedVersions,
+	})
+	go c.WriteJSON(welcome)
 
+	for {
+		_, data, err := c.Conn.ReadMessage()
 		if err != nil {
 			fmt.Println("Game WebSocket read error:", err)
 			return
 		}
 
-		switch msg.Type {
-		case model.Join:
-			if !p.gameMessageHandler.HandleJoin(c, msg) {
-				continue
-			}
-		case model.Answer:
-			if !p.gameMessageHandler.HandleAnswer(c, msg) {
-				continue
-			}
-		case model.Leave:
-			p.LeaveRoom(c)
-		case model.Typing:
-			if !p.gameMessageHandler.HandleTyping(c, msg) {
-				continue
-			}
-		case model.Ping:
-			if timestamp, ok := msg.Payload["timestamp"].(float64); ok {
-				c.SendPong(int64(timestamp))
-			}
-		case model.Pong:
-			if timestamp, ok := msg.Payload["timestamp"].(float64); ok {
-				c.HandlePong(int64(timestamp))
-			}
-		default:
-			fmt.Println("Unknown game message type:", msg.Type)
+		if !c.AllowMessage() {
+			p.SendError(c, model.ErrorRateLimited, "Too many messages, slow down")
+			continue
 		}
+
+		payload, decodeErr := protocol.DecodeInbound(data)
+		if decodeErr != nil {
+			p.SendError(c, decodeErr.Code, decodeErr.Message)
+			continue
+		}
+
+		p.HandleMessage(c, payload)
 	}
 }
 
-func (p *GamePool) JoinRoom(c *GameClient, roomID uint) {
-	c.RoomID = roomID
-	if p.RoomCount(roomID) >= MaxRoomCapacity {
-		fmt.Println("Room is full, cannot join:", roomID)
+func (p *GamePool) HandleMessage(c *GameClient, payload protocol.InboundPayload) {
+	p.forgetLeftRoom(c)
+	if p.relay(c, payload) {
 		return
 	}
-	p.Register <- c
-
-	remainingTime := p.gameTimerManager.GetRemainingCountdownTime(roomID)
-	if remainingTime > 0 {
-		message := NewGameMessage().
-			SetMessageType(model.TimerStarted).
-			WithRoomId(roomID).
-			WithDuration(remainingTime).
-			Build()
 
-		c.WriteJSON(message)
+	switch request := payload.(type) {
+	case *protocol.JoinRequest:
+		p.gameMessageHandler.HandleJoin(c, request)
+	case *protocol.AnswerRequest:
+		p.gameMessageHandler.HandleAnswer(c, request)
+	case *protocol.LeaveRequest:
+		p.LeaveRoom(c)
+	case *protocol.ResyncRequest:
+		p.gameMessageHandler.HandleResync(c, request)
+	case *protocol.StartGameRequest:
+		p.gameMessageHandler.HandleStartGame(c, request)
+	case *protocol.CancelCountdownRequest:
+		p.gameMessageHandler.HandleCancelCountdown(c, request)
+	case *protocol.ExtendCountdownRequest:
+		p.gameMessageHandler.HandleExtendCountdown(c, request)
+	case *protocol.JoinTeamRequest:
+		p.gameMessageHandler.HandleJoinTeam(c, request)
+	case *protocol.AddBotRequest:
+		p.gameMessageHandler.HandleAddBot(c, request)
+	case *protocol.RemoveBotRequest:
+		p.gameMessageHandler.HandleRemoveBot(c, request)
+	case *protocol.RematchRequest:
+		p.gameMessageHandler.HandleRematch(c, request)
+	case *protocol.TypingRequest:
+		p.gameMessageHandler.HandleTyping(c, request)
+	case *protocol.PingRequest:
+		c.SendPong(request.Timestamp)
+	case *protocol.PongRequest:
+		c.HandlePong(request.Timestamp)
+	default:
+		p.SendError(c, model.ErrorUnknownMessage, fmt.Sprintf("Unknown message type %q", payload.MessageType()))
 	}
 }
 
-func (p *GamePool) LeaveRoom(c *GameClient) {
-	p.Unregister <- c
+func (p *GamePool) JoinRoom(c *GameClient, roomID uint) {
+	var room *model.Room
+	if !c.IsBot {
+		room = p.loadRoom(roomID)
+		kicked, muted := memberStatus(room, c.UserId)
+		if kicked {
+			p.sendRoomError(c, roomID, model.ErrorKicked, "You have been removed from this room")
+			return
+		}
+		p.setMuted(roomID, c.UserId, muted)
+	}
+	if c.RoomID != 0 && c.RoomID != roomID && p.IsMember(c) {
+		p.LeaveRoom(c)
+	}
+
+	accepted := make(chan bool, 1)
+	p.joins <- joinRequest{client: c, roomID: roomID, room: room, accepted: accepted}
+	<-accepted
 }
 
-func (p *GamePool) BroadcastMessage(c *GameClient, msg model.GameMessage) {
-	msg.Payload["user_id"] = c.UserId
-	msg.Payload["room_id"] = c.RoomID
-	p.Broadcast <- msg
+func (p *GamePool) KickFromRoom(roomID uint, userID string) {
+	if owner := p.remoteOwner(roomID); owner != "" {
+		p.publish(owner, busEnvelope{
+			Kind:   busKick,
+			RoomID: roomID,
+			UserID: userID,
+		})
+		return
+	}
+	p.kick(roomID, userID)
 }
 
-func (p *GamePool) handleCountdownEnd(roomID uint) {
-	gameRoomState := p.gameStateManager.GetRoomState(roomID)
-	if gameRoomState == nil || gameRoomState.Started {
+func (p *GamePool) MuteInRoom(roomID uint, userID string, muted bool) {
+	if owner := p.remoteOwner(roomID); owner != "" {
+		p.publish(owner, busEnvelope{
+			Kind:   busMute,
+			RoomID: roomID,
+			UserID: userID,
+			Muted:  muted,
+		})
 		return
 	}
+	p.setMuted(roomID, userID, muted)
+}
 
-	gameRoomState.Started = true
-	gameRoomState.CharSet = util.GenerateRandomWord()
-	gameRoomState.Round = InitialRound
-	gameRoomState.TimeLimit = InitialTimeLimit
-	gameRoomState.CountdownStarted = false
-	gameRoomState.TurnIndex = InitialTurnIndex
+func (p *GamePool) IsMuted(roomID uint, userID string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.muted[roomID][userID]
+}
 
-	message := NewGameMessage().
-		SetMessageType(model.StartGame).
-		WithRoomId(roomID).
-		WithCharSet(gameRoomState.CharSet).
-		WithRound(gameRoomState.Round).
-		WithTimeLimit(gameRoomState.TimeLimit).
-		Build()
+func (p *GamePool) IsMember(c *GameClient) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Rooms[c.RoomID][c.UserId] == c
+}
 
-	p.BroadcastToRoom(roomID, message)
+func (p *GamePool) forgetLeftRoom(c *GameClient) {
+	if c.RoomID == 0 {
+		return
+	}
 
-	game := NewGameEngine(p, gameRoomState)
-	game.StartNextTurn()
+	p.mu.RLock()
+	joined := p.Rooms[c.RoomID][c.UserId] == c || p.relayed[c.RoomID][c.UserId] == c
+	p.mu.RUnlock()
+	if !joined {
+		c.RoomID = 0
+	}
 }
 
-func (p *GamePool) BroadcastTimerStarted(roomID uint, duration int) {
+func (p *GamePool) kick(roomID uint, userID string) {
 	p.mu.RLock()
-	clients := p.Rooms[roomID]
-	if len(clients) == 0 {
-		p.mu.RUnlock()
+	client := p.Rooms[roomID][userID]
+	p.mu.RUnlock()
+
+	if client == nil {
 		return
 	}
-	for _, client := range clients {
-		message := NewGameMessage().
-			SetMessageType(model.TimerStarted).
-			WithRoomId(roomID).
-			WithDuration(duration).
-			Build()
+	p.SendError(client, model.ErrorKicked, "You were removed from the room by the host")
+	go p.LeaveRoom(client)
+}
 
-		go client.WriteJSON(message)
+func (p *GamePool) setMuted(roomID uint, userID string, muted bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !muted {
+		delete(p.muted[roomID], userID)
+		if len(p.muted[roomID]) == 0 {
+			delete(p.muted, roomID)
+		}
+		return
 	}
-	p.mu.RUnlock()
+	if p.muted[roomID] == nil {
+		p.muted[roomID] = make(map[string]bool)
+	}
+	p.muted[roomID][userID] = true
+}
+
+func (p *GamePool) LeaveRoom(c *GameClient) {
+	p.Unregister <- c
+}
+
+func (p *GamePool) DisconnectClient(c *GameClient) {
+	p.Disconnect <- c
+}
+
+func (p *GamePool) SendError(c *GameClient, code string, text string) {
+	p.sendRoomError(c, c.RoomID, code, text)
+}
+
+func (p *GamePool) sendRoomError(c *GameClient, roomID uint, code string, text string) {
+	message := protocol.NewMessage(protocol.ErrorEvent{
+		RoomID:  roomID,
+		UserID:  c.UserId,
+		Code:    code,
+		Message: text,
+	})
+
+	go c.WriteJSON(message)
+}
+
+func (p *GamePool) BroadcastTimerStarted(roomID uint, duration int) {
+	message := protocol.NewMessage(protocol.TimerStartedEvent{
+		RoomID:   roomID,
+		Duration: duration,
+	})
+
+	p.BroadcastToRoom(roomID, message)
 }
 
 func (p *BasePool[T]) RoomCount(roomID uint) int {
@@ -233,10 +483,35 @@ func (p *BasePool[T]) RoomCount(roomID uint) int {
 	return len(p.Rooms[roomID])
 }
 
-func (p *GamePool) BroadcastToRoom(roomID uint, msg model.GameMessage) {
+func (p *GamePool) RoleCounts(roomID uint) (int, int) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
+
+	players, spectators := 0, 0
 	for _, client := range p.Rooms[roomID] {
+		if client.Spectator {
+			spectators++
+		} else {
+			players++
+		}
+	}
+	return players, spectators
+}
+
+func (p *GamePool) BroadcastToRoom(roomID uint, msg model.GameMessage) {
+	remote := make(map[string][]string)
+
+	p.mu.RLock()
+	for _, client := range p.Rooms[roomID] {
+		if client.Node != "" {
+			remote[client.Node] = append(remote[client.Node], client.UserId)
+			continue
+		}
 		go client.WriteJSON(msg)
 	}
+	p.mu.RUnlock()
+
+	for node, userIDs := range remote {
+		p.deliverRemote(node, roomID, userIDs, msg)
+	}
 }
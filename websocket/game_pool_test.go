@@ -0,0 +1,180 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/lakshya1goel/Playzio/bootstrap/redis"
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+func TestGuestsJoinAsSeparatePlayers(t *testing.T) {
+	pool, server := newTestPool(t)
+	_, clients := joinTestRoom(t, pool, server, 1, "guest:a", "guest:b", "guest:c")
+
+	if count := pool.RoomCount(1); count != len(clients) {
+		t.Fatalf("expected %d registered clients, got %d", len(clients), count)
+	}
+
+	clients[0].send(t, model.StartGame, map[string]any{"duration": 0})
+	turn := clients[0].expect(t, model.NextTurn, nil)
+	if lives := payloadUint(turn, "lives"); lives != model.DefaultLives {
+		t.Fatalf("expected %d lives for %s, got %d", model.DefaultLives, payloadString(turn, "user_id"), lives)
+	}
+}
+
+func expectErrorCode(t *testing.T, client *testClient, code string) {
+	t.Helper()
+	client.expect(t, model.Error, func(msg testMessage) bool {
+		return msg.Payload["code"] == code
+	})
+}
+
+func TestClientThatLeftCannotActInTheRoom(t *testing.T) {
+	pool, server := newTestPool(t)
+	_, clients := joinTestRoom(t, pool, server, 10, "user:1", "guest:a", "guest:b")
+
+	clients[2].send(t, model.Leave, nil)
+	clients[0].expect(t, model.UserLeft, fromUser("guest:b"))
+
+	clients[2].send(t, model.Typing, map[string]any{"text": "hi"})
+	expectErrorCode(t, clients[2], model.ErrorNotJoined)
+	clients[2].send(t, model.StartGame, map[string]any{"duration": 0})
+	expectErrorCode(t, clients[2], model.ErrorNotJoined)
+	clients[0].expectNone(t, model.Typing, 200*time.Millisecond)
+}
+
+func TestJoiningAnotherRoomLeavesTheCurrentOne(t *testing.T) {
+	pool, server := newTestPool(t)
+	_, clients := joinTestRoom(t, pool, server, 13, "user:1", "guest:a")
+
+	clients[1].send(t, model.Join, map[string]any{"room_id": 14})
+	clients[1].expect(t, model.UserJoined, fromUser("guest:a"))
+	clients[0].expect(t, model.UserLeft, fromUser("guest:a"))
+	if count := pool.RoomCount(13); count != 1 {
+		t.Fatalf("expected 1 client left in room 13, got %d", count)
+	}
+
+	clients[0].send(t, model.Leave, nil)
+	deadline := time.Now().Add(testMessageTimeout)
+	for pool.gameStateManager.GetRoom(13) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("room 13 was never closed after its last player left")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestInvalidInputIsReportedToTheSender(t *testing.T) {
+	pool, server := newTestPool(t)
+
+	client := dialTestClient(t, server, "guest:a")
+	client.send(t, model.Answer, map[string]any{"answer": "word"})
+	expectErrorCode(t, client, model.ErrorNotJoined)
+
+	client.send(t, "dance", nil)
+	expectErrorCode(t, client, model.ErrorUnknownMessage)
+
+	if err := client.conn.WriteMessage(gorilla.TextMessage, []byte("{not json")); err != nil {
+		t.Fatal(err)
+	}
+	expectErrorCode(t, client, model.ErrorInvalidPayload)
+
+	client.send(t, model.Join, map[string]any{"room_id": "lobby"})
+	expectErrorCode(t, client, model.ErrorInvalidPayload)
+
+	_, clients := joinTestRoom(t, pool, server, 7, "user:1", "guest:b")
+	clients[1].send(t, model.Answer, map[string]any{"answer": 42})
+	expectErrorCode(t, clients[1], model.ErrorInvalidPayload)
+
+	clients[1].send(t, model.Answer, map[string]any{"answer": "word"})
+	expectErrorCode(t, clients[1], model.ErrorGameNotStarted)
+
+	for range MessageRateLimit + 1 {
+		clients[0].send(t, model.Typing, map[string]any{"text": "w"})
+	}
+	expectErrorCode(t, clients[0], model.ErrorRateLimited)
+}
+
+func TestPlayersOnDifferentNodesShareARoom(t *testing.T) {
+	bus := redis.NewMemory()
+	owner, ownerServer := newTestNode(t, bus)
+	edge, edgeServer := newTestNode(t, bus)
+
+	alice := dialTestClient(t, ownerServer, "user:1")
+	alice.send(t, model.Join, map[string]any{"room_id": 7})
+	alice.expect(t, model.UserJoined, fromUser("user:1"))
+
+	bob := joinWithResumeToken(t, edgeServer, 7, "user:2", "")
+	token := payloadString(bob.expectAll(t, model.ResumeToken, model.UserJoined)[model.ResumeToken], "resume_token")
+	alice.expect(t, model.UserJoined, fromUser("user:2"))
+
+	if edge.gameStateManager.GetRoom(7) != nil {
+		t.Fatal("expected the room to live only on the node that owns it")
+	}
+	if count := owner.RoomCount(7); count != 2 {
+		t.Fatalf("expected both players registered on the owner, got %d", count)
+	}
+
+	current, charSet := startTestGame(t, []*testClient{alice, bob})
+	bob.expect(t, model.NextTurn, nil)
+	current.send(t, model.Answer, map[string]any{"answer": validWord(t, owner, charSet)})
+	for _, client := range []*testClient{alice, bob} {
+		answer := client.expect(t, model.Answer, fromUser(current.userID))
+		if answer.Payload["correct"] != true {
+			t.Fatalf("expected a correct answer, got %v", answer.Payload)
+		}
+	}
+
+	bob.conn.Close()
+	alice.expect(t, model.UserDisconnected, fromUser("user:2"))
+
+	resumed := joinWithResumeToken(t, ownerServer, 7, "user:2", token)
+	resync := resumed.expect(t, model.Resync, nil)
+	if resync.Payload["started"] != true {
+		t.Fatalf("expected resync of the running game, got %v", resync.Payload)
+	}
+}
+
+func TestModerationDoesNotClaimAnUnownedRoom(t *testing.T) {
+	bus := redis.NewMemory()
+	pool, _ := newTestNode(t, bus)
+
+	pool.KickFromRoom(15, "user:2")
+	pool.MuteInRoom(15, "user:3", true)
+	if owner, _ := bus.LeaseOwner(roomLeaseKey(15)); owner != "" {
+		t.Fatalf("expected room 15 to stay unowned, got %q", owner)
+	}
+}
+
+func TestNodeThatLosesARoomLeaseEvictsItsPlayers(t *testing.T) {
+	bus := redis.NewMemory()
+	first, firstServer := newTestNode(t, bus)
+	first.leaseTTL = 50 * time.Millisecond
+	second, secondServer := newTestNode(t, bus)
+
+	alice := dialTestClient(t, firstServer, "user:1")
+	alice.send(t, model.Join, map[string]any{"room_id": 8})
+	alice.expect(t, model.UserJoined, fromUser("user:1"))
+
+	time.Sleep(100 * time.Millisecond)
+	bob := dialTestClient(t, secondServer, "user:2")
+	bob.send(t, model.Join, map[string]any{"room_id": 8})
+	bob.expect(t, model.UserJoined, fromUser("user:2"))
+	if second.gameStateManager.GetRoom(8) == nil {
+		t.Fatal("expected the second node to take over the expired room")
+	}
+
+	first.renewOwnedRooms()
+	expectErrorCode(t, alice, model.ErrorRoomMoved)
+	if first.gameStateManager.GetRoom(8) != nil {
+		t.Fatal("expected the first node to stop running the room it lost")
+	}
+
+	alice.send(t, model.Join, map[string]any{"room_id": 8})
+	bob.expect(t, model.UserJoined, fromUser("user:1"))
+	if count := second.RoomCount(8); count != 2 {
+		t.Fatalf("expected both players on the new owner, got %d", count)
+	}
+}
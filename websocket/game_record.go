@@ -0,0 +1,53 @@
+package websocket
+
+import (
+	"slices"
+	"time"
+
+	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+func newGameRecord(state *model.GameRoomState) *model.Game {
+	game := &model.Game{
+		RoomID:       state.RoomID,
+		Language:     state.Language,
+		Mode:         state.Settings.Mode,
+		WinnerID:     state.WinnerID,
+		RoundsPlayed: state.Round,
+		StartedAt:    state.StartedAt,
+		EndedAt:      time.Now(),
+		Players:      make([]model.GamePlayer, 0, len(state.Players)),
+		Turns:        slices.Clone(state.Turns),
+	}
+
+	if state.WinningTeam != model.NoTeam {
+		winningTeam := state.WinningTeam
+		game.WinningTeam = &winningTeam
+	}
+
+	for _, participantID := range state.Players {
+		team, inTeam := state.Teams[participantID]
+		player := model.GamePlayer{
+			ParticipantID: participantID,
+			UserName:      state.PlayerNames[participantID],
+			IsWinner:      participantID == state.WinnerID || (inTeam && team == state.WinningTeam),
+			WordsAnswered: state.WordsAnswered[participantID],
+			LivesLost:     state.LivesLost[participantID],
+			FinalLives:    state.Lives[participantID],
+			FinalPoints:   state.Points[participantID],
+			IsBot:         util.IsBotParticipant(participantID),
+		}
+		if inTeam && state.Settings.Mode == model.ModeTeams {
+			player.Team = &team
+		}
+		if userID, ok := util.ParseUserParticipantID(participantID); ok {
+			player.UserID = &userID
+		} else if guestID, ok := util.ParseGuestParticipantID(participantID); ok {
+			player.GuestID = &guestID
+		}
+		game.Players = append(game.Players, player)
+	}
+
+	return game
+}
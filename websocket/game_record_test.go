@@ -0,0 +1,148 @@
+package websocket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lakshya1goel/Playzio/domain"
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"gorm.io/gorm"
+)
+
+func finishedState(mode string) *model.GameRoomState {
+	state := &model.GameRoomState{
+		RoomID:        5,
+		Language:      "en",
+		Settings:      model.DefaultGameSettings(),
+		Players:       []string{"user:7", "guest:abc", "bot:xyz"},
+		PlayerNames:   map[string]string{"user:7": "Ada", "guest:abc": "Guest", "bot:xyz": "Bot"},
+		Lives:         map[string]int{"user:7": 2, "guest:abc": 0, "bot:xyz": 1},
+		Points:        map[string]int{"user:7": 30, "guest:abc": 10, "bot:xyz": 20},
+		WordsAnswered: map[string]int{"user:7": 3, "guest:abc": 1, "bot:xyz": 2},
+		LivesLost:     map[string]int{"user:7": 1, "guest:abc": 3, "bot:xyz": 2},
+		Teams:         map[string]int{},
+		WinningTeam:   model.NoTeam,
+		Turns:         []model.GameTurn{{TurnNumber: 1, ParticipantID: "user:7", Answer: "word"}},
+		Round:         4,
+		WinnerID:      "user:7",
+	}
+	state.Settings.Mode = mode
+	if mode == model.ModeTeams {
+		state.Teams = map[string]int{"user:7": 0, "guest:abc": 1, "bot:xyz": 0}
+		state.WinningTeam = 0
+		state.WinnerID = ""
+	}
+	return state
+}
+
+func TestNewGameRecord(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		winners map[string]bool
+		teams   bool
+	}{
+		{name: "free for all", mode: model.ModeFreeForAll, winners: map[string]bool{"user:7": true}},
+		{name: "teams", mode: model.ModeTeams, winners: map[string]bool{"user:7": true, "bot:xyz": true}, teams: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := finishedState(tt.mode)
+			game := newGameRecord(state)
+
+			if game.RoomID != 5 || game.Mode != tt.mode || game.RoundsPlayed != 4 || len(game.Players) != 3 {
+				t.Fatalf("unexpected game record %+v", game)
+			}
+			if tt.teams != (game.WinningTeam != nil) {
+				t.Fatalf("expected winning team set to be %v, got %v", tt.teams, game.WinningTeam)
+			}
+			for _, player := range game.Players {
+				if player.IsWinner != tt.winners[player.ParticipantID] {
+					t.Fatalf("expected %s winner to be %v", player.ParticipantID, tt.winners[player.ParticipantID])
+				}
+				if tt.teams != (player.Team != nil) {
+					t.Fatalf("expected %s team set to be %v", player.ParticipantID, tt.teams)
+				}
+				if player.FinalPoints != state.Points[player.ParticipantID] || player.FinalLives != state.Lives[player.ParticipantID] {
+					t.Fatalf("unexpected final score for %s: %+v", player.ParticipantID, player)
+				}
+			}
+
+			user, guest, bot := game.Players[0], game.Players[1], game.Players[2]
+			if user.UserID == nil || *user.UserID != 7 || user.GuestID != nil || user.IsBot {
+				t.Fatalf("unexpected user player %+v", user)
+			}
+			if guest.GuestID == nil || *guest.GuestID != "abc" || guest.UserID != nil {
+				t.Fatalf("unexpected guest player %+v", guest)
+			}
+			if !bot.IsBot || bot.UserID != nil || bot.GuestID != nil {
+				t.Fatalf("unexpected bot player %+v", bot)
+			}
+
+			game.Turns[0].GameID = 99
+			state.Turns = append(state.Turns[:0], model.GameTurn{TurnNumber: 2})
+			if game.Turns[0].TurnNumber != 1 {
+				t.Fatalf("expected the record to keep its own turns, got %+v", game.Turns)
+			}
+		})
+	}
+}
+
+type testGameRecorder struct {
+	release  chan struct{}
+	recorded chan *model.Game
+	deadline bool
+}
+
+func (r *testGameRecorder) RecordGame(ctx context.Context, game *model.Game) (map[string]int, *domain.HttpError) {
+	<-r.release
+	_, r.deadline = ctx.Deadline()
+	game.ID = 42
+	r.recorded <- game
+	return map[string]int{"user:1": 16}, nil
+}
+
+func TestGameOverCarriesRatingChangesWithoutBlockingTheRoom(t *testing.T) {
+	settings := model.DefaultGameSettings()
+	settings.Lives = 1
+	settings.LifeCap = 1
+	settings.MaxTurnTime = 1
+	settings.MinTurnTime = 1
+	pool, server := newTestPool(t, model.Room{Model: gorm.Model{ID: 12}, Settings: settings})
+	recorder := &testGameRecorder{release: make(chan struct{}), recorded: make(chan *model.Game, 1)}
+	pool.games = recorder
+
+	_, clients := joinTestRoom(t, pool, server, 12, "user:1", "guest:a")
+	startTestGame(t, clients)
+	clients[0].expect(t, model.TurnEnded, nil)
+
+	clients[0].send(t, model.Resync, nil)
+	if resync := clients[0].expect(t, model.Resync, nil); resync.Payload["started"] != false {
+		t.Fatalf("expected the game to have ended while it is saved, got %v", resync.Payload)
+	}
+	clients[0].expectNone(t, model.GameOver, 200*time.Millisecond)
+
+	close(recorder.release)
+	gameOver := clients[1].expect(t, model.GameOver, nil)
+	if payloadUint(gameOver, "game_id") != 42 {
+		t.Fatalf("expected game 42, got %v", gameOver.Payload["game_id"])
+	}
+	changes, _ := gameOver.Payload["rating_changes"].(map[string]any)
+	if changes["user:1"] != float64(16) {
+		t.Fatalf("expected rating change for user:1, got %v", gameOver.Payload["rating_changes"])
+	}
+
+	select {
+	case game := <-recorder.recorded:
+		if game.RoomID != 12 || len(game.Players) != 2 {
+			t.Fatalf("unexpected recorded game %+v", game)
+		}
+	case <-time.After(testMessageTimeout):
+		t.Fatal("game was not recorded")
+	}
+	if !recorder.deadline {
+		t.Fatal("expected the game to be saved with a deadline")
+	}
+}
@@ -0,0 +1,762 @@
+package websocket
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
+)
+
+type gameEventType int
+
+const (
+	joinEvent gameEventType = iota
+	leaveEvent
+	disconnectEvent
+	graceExpiredEvent
+	resyncEvent
+	startRequestEvent
+	cancelCountdownEvent
+	extendCountdownEvent
+	chooseTeamEvent
+	addBotEvent
+	removeBotEvent
+	rematchEvent
+	answerEvent
+	turnTimeoutEvent
+	countdownEndEvent
+	closeEvent
+)
+
+type gameEvent struct {
+	eventType      gameEventType
+	client         *GameClient
+	userID         string
+	answer         string
+	turnID         int
+	countdownID    int
+	duration       time.Duration
+	team           int
+	difficulty     string
+	disconnectedAt time.Time
+	accepted       chan<- bool
+}
+
+type GameRoom struct {
+	pool        *GamePool
+	State       *model.GameRoomState
+	engine      GameEngine
+	connected   map[string]*GameClient
+	spectators  map[string]*GameClient
+	bots        map[string]*Bot
+	botCount    int
+	capacity    atomic.Int32
+	joining     atomic.Int32
+	closing     atomic.Bool
+	gracePeriod time.Duration
+	events      chan gameEvent
+	done        chan struct{}
+}
+
+func NewGameRoom(pool *GamePool, state *model.GameRoomState) *GameRoom {
+	room := &GameRoom{
+		pool:        pool,
+		State:       state,
+		connected:   make(map[string]*GameClient),
+		spectators:  make(map[string]*GameClient),
+		bots:        make(map[string]*Bot),
+		gracePeriod: pool.gracePeriod,
+		events:      make(chan gameEvent, RoomEventBufferSize),
+		done:        make(chan struct{}),
+	}
+	room.capacity.Store(int32(state.Settings.MaxPlayers))
+	room.engine = NewGameEngine(pool, room)
+	return room
+}
+
+func (r *GameRoom) Capacity() int {
+	return int(r.capacity.Load())
+}
+
+func (r *GameRoom) Run() {
+	defer close(r.done)
+
+	for event := range r.events {
+		switch event.eventType {
+		case joinEvent:
+			event.accepted <- r.handleJoin(event.client)
+			r.joining.Add(-1)
+			r.notifyIfIdle()
+		case leaveEvent:
+			r.handleLeave(event.client)
+		case disconnectEvent:
+			r.handleDisconnect(event.client)
+		case graceExpiredEvent:
+			r.handleGraceExpired(event.userID, event.disconnectedAt)
+		case resyncEvent:
+			r.sendResync(event.client)
+		case startRequestEvent:
+			r.handleStartRequest(event.client, event.duration)
+		case cancelCountdownEvent:
+			r.handleCancelCountdown(event.client)
+		case extendCountdownEvent:
+			r.handleExtendCountdown(event.client, event.duration)
+		case chooseTeamEvent:
+			r.handleChooseTeam(event.client, event.team)
+		case addBotEvent:
+			r.handleAddBot(event.client, event.difficulty)
+		case removeBotEvent:
+			r.handleRemoveBot(event.client, event.userID)
+		case rematchEvent:
+			r.handleRematch(event.client)
+		case answerEvent:
+			if r.spectators[event.client.UserId] == event.client {
+				r.pool.SendError(event.client, model.ErrorSpectator, "Spectators cannot answer")
+				continue
+			}
+			if _, away := r.State.Disconnected[event.client.UserId]; away {
+				r.pool.SendError(event.client, model.ErrorNotJoined, "Resume your seat before answering")
+				continue
+			}
+			r.engine.HandleAnswer(event.client, event.answer)
+		case turnTimeoutEvent:
+			r.engine.HandleTurnTimeout(event.turnID)
+		case countdownEndEvent:
+			r.handleCountdownEnd(event.countdownID)
+		case closeEvent:
+			r.pool.gameTimerManager.StopCountdown(r)
+			r.engine.Stop()
+			for _, timer := range r.State.DisconnectTimers {
+				timer.Stop()
+			}
+			for _, bot := range r.bots {
+				bot.Stop()
+			}
+			return
+		}
+	}
+}
+
+func (r *GameRoom) post(event gameEvent) {
+	select {
+	case r.events <- event:
+	case <-r.done:
+	}
+}
+
+func (r *GameRoom) Join(c *GameClient, accepted chan<- bool) {
+	r.joining.Add(1)
+	r.post(gameEvent{eventType: joinEvent, client: c, accepted: accepted})
+}
+
+func (r *GameRoom) Leave(c *GameClient) {
+	r.post(gameEvent{eventType: leaveEvent, client: c})
+}
+
+func (r *GameRoom) Disconnect(c *GameClient) {
+	r.post(gameEvent{eventType: disconnectEvent, client: c})
+}
+
+func (r *GameRoom) RequestResync(c *GameClient) {
+	r.post(gameEvent{eventType: resyncEvent, client: c})
+}
+
+func (r *GameRoom) RequestStart(c *GameClient, duration time.Duration) {
+	r.post(gameEvent{eventType: startRequestEvent, client: c, duration: duration})
+}
+
+func (r *GameRoom) CancelCountdown(c *GameClient) {
+	r.post(gameEvent{eventType: cancelCountdownEvent, client: c})
+}
+
+func (r *GameRoom) ExtendCountdown(c *GameClient, duration time.Duration) {
+	r.post(gameEvent{eventType: extendCountdownEvent, client: c, duration: duration})
+}
+
+func (r *GameRoom) ChooseTeam(c *GameClient, team int) {
+	r.post(gameEvent{eventType: chooseTeamEvent, client: c, team: team})
+}
+
+func (r *GameRoom) AddBot(c *GameClient, difficulty string) {
+	r.post(gameEvent{eventType: addBotEvent, client: c, difficulty: difficulty})
+}
+
+func (r *GameRoom) RemoveBot(c *GameClient, botID string) {
+	r.post(gameEvent{eventType: removeBotEvent, client: c, userID: botID})
+}
+
+func (r *GameRoom) RequestRematch(c *GameClient) {
+	r.post(gameEvent{eventType: rematchEvent, client: c})
+}
+
+func (r *GameRoom) SubmitAnswer(c *GameClient, answer string) {
+	r.post(gameEvent{eventType: answerEvent, client: c, answer: answer})
+}
+
+func (r *GameRoom) Close() {
+	r.closing.Store(true)
+	r.post(gameEvent{eventType: closeEvent})
+}
+
+func (r *GameRoom) handleJoin(c *GameClient) bool {
+	if r.closing.Load() {
+		r.pool.sendRoomError(c, r.State.RoomID, model.ErrorRoomMoved, "The room moved to another server, join again")
+		return false
+	}
+	if c.Spectator {
+		return r.handleSpectatorJoin(c)
+	}
+
+	_, away := r.State.Disconnected[c.UserId]
+	if away && (c.ResumeToken == "" || c.ResumeToken != r.State.ResumeTokens[c.UserId]) {
+		r.pool.sendRoomError(c, r.State.RoomID, model.ErrorInvalidResumeToken, "Invalid resume token")
+		return false
+	}
+	reserved := away || r.connected[c.UserId] != nil || r.bots[c.UserId] != nil
+	if !reserved && r.seatsTaken() >= r.Capacity() {
+		fmt.Println("Room is full, cannot join:", r.State.RoomID)
+		r.pool.sendRoomError(c, r.State.RoomID, model.ErrorRoomFull, "Room is full")
+		return false
+	}
+
+	r.admit(c)
+	delete(r.spectators, c.UserId)
+	r.connected[c.UserId] = c
+
+	if away {
+		r.resume(c)
+		return true
+	}
+
+	r.pool.gameStateManager.AddPlayer(r.State.RoomID, c.UserId)
+	r.State.PlayerNames[c.UserId] = c.UserName
+	r.issueResumeToken(c)
+
+	if remainingTime := r.pool.gameTimerManager.GetRemainingCountdownTime(r); remainingTime > 0 {
+		message := protocol.NewMessage(protocol.TimerStartedEvent{
+			RoomID:   r.State.RoomID,
+			Duration: remainingTime,
+		})
+
+		go c.WriteJSON(message)
+	}
+
+	message := protocol.NewMessage(protocol.UserJoinedEvent{
+		RoomID:   r.State.RoomID,
+		UserID:   c.UserId,
+		UserName: c.UserName,
+		Role:     model.RolePlayer,
+		HostID:   r.host(),
+	})
+
+	r.pool.BroadcastToRoom(r.State.RoomID, message)
+	return true
+}
+
+func (r *GameRoom) handleSpectatorJoin(c *GameClient) bool {
+	if r.spectators[c.UserId] == nil && len(r.spectators) >= MaxSpectators {
+		fmt.Println("Spectator limit reached, cannot join:", r.State.RoomID)
+		r.pool.sendRoomError(c, r.State.RoomID, model.ErrorSpectatorsFull, "Spectator limit reached")
+		return false
+	}
+
+	r.admit(c)
+	r.spectators[c.UserId] = c
+	if r.connected[c.UserId] != nil {
+		delete(r.connected, c.UserId)
+		r.handleSeatDropped(c.UserId, c.UserName)
+	}
+
+	message := protocol.NewMessage(protocol.UserJoinedEvent{
+		RoomID:   r.State.RoomID,
+		UserID:   c.UserId,
+		UserName: c.UserName,
+		Role:     model.RoleSpectator,
+	})
+
+	r.pool.BroadcastToRoom(r.State.RoomID, message)
+	r.sendResync(c)
+	return true
+}
+
+func (r *GameRoom) admit(c *GameClient) {
+	if c.RoomID != r.State.RoomID {
+		c.RoomID = r.State.RoomID
+	}
+	r.pool.addMember(r.State.RoomID, c)
+}
+
+func (r *GameRoom) handleSpectatorLeave(c *GameClient) {
+	delete(r.spectators, c.UserId)
+
+	message := protocol.NewMessage(protocol.UserLeftEvent{
+		RoomID:   r.State.RoomID,
+		UserID:   c.UserId,
+		UserName: c.UserName,
+		Role:     model.RoleSpectator,
+	})
+
+	r.pool.BroadcastToRoom(r.State.RoomID, message)
+	r.notifyIfIdle()
+}
+
+func (r *GameRoom) handleLeave(c *GameClient) {
+	if r.spectators[c.UserId] == c {
+		r.handleSpectatorLeave(c)
+		return
+	}
+	if r.connected[c.UserId] != c {
+		return
+	}
+	delete(r.connected, c.UserId)
+
+	r.releaseSeat(c.UserId, c.UserName)
+	r.notifyIfIdle()
+}
+
+func (r *GameRoom) handleDisconnect(c *GameClient) {
+	if r.spectators[c.UserId] == c {
+		r.handleSpectatorLeave(c)
+		return
+	}
+	if r.connected[c.UserId] != c {
+		return
+	}
+	delete(r.connected, c.UserId)
+
+	r.handleSeatDropped(c.UserId, c.UserName)
+	r.notifyIfIdle()
+}
+
+func (r *GameRoom) handleSeatDropped(userID string, userName string) {
+	_, seated := r.State.Lives[userID]
+	_, away := r.State.Disconnected[userID]
+	if !seated || away {
+		return
+	}
+
+	disconnectedAt := time.Now()
+	r.State.Disconnected[userID] = disconnectedAt
+	r.State.DisconnectTimers[userID] = time.AfterFunc(r.gracePeriod, func() {
+		r.post(gameEvent{eventType: graceExpiredEvent, userID: userID, disconnectedAt: disconnectedAt})
+	})
+
+	message := protocol.NewMessage(protocol.UserDisconnectedEvent{
+		RoomID:      r.State.RoomID,
+		UserID:      userID,
+		UserName:    userName,
+		GracePeriod: int(r.gracePeriod.Seconds()),
+	})
+
+	r.pool.BroadcastToRoom(r.State.RoomID, message)
+}
+
+func (r *GameRoom) handleGraceExpired(userID string, disconnectedAt time.Time) {
+	if since, away := r.State.Disconnected[userID]; !away || !since.Equal(disconnectedAt) {
+		return
+	}
+
+	r.releaseSeat(userID, r.State.PlayerNames[userID])
+	r.notifyIfIdle()
+}
+
+func (r *GameRoom) resume(c *GameClient) {
+	r.clearDisconnect(c.UserId)
+	r.issueResumeToken(c)
+
+	message := protocol.NewMessage(protocol.UserReconnectedEvent{
+		RoomID:   r.State.RoomID,
+		UserID:   c.UserId,
+		UserName: c.UserName,
+	})
+
+	r.pool.BroadcastToRoom(r.State.RoomID, message)
+	r.sendResync(c)
+}
+
+func (r *GameRoom) handleStartRequest(c *GameClient, duration time.Duration) {
+	if !r.requireHost(c) {
+		return
+	}
+	if r.State.Started {
+		r.pool.SendError(c, model.ErrorGameInProgress, "The game has already started")
+		return
+	}
+	if r.State.Finished {
+		r.pool.SendError(c, model.ErrorGameFinished, "The game has finished, request a rematch instead")
+		return
+	}
+	r.refreshSettings()
+	if r.readyPlayers() < r.minPlayersToStart() {
+		r.pool.SendError(c, model.ErrorNotEnoughPlayers, "Not enough players to start the game")
+		return
+	}
+
+	r.pool.gameTimerManager.StartCountdown(r, min(duration, MaxCountdownDuration))
+}
+
+func (r *GameRoom) handleCancelCountdown(c *GameClient) {
+	if !r.requireHost(c) {
+		return
+	}
+	if !r.State.CountdownStarted || r.State.Started {
+		r.pool.SendError(c, model.ErrorNoCountdown, "No countdown is running")
+		return
+	}
+
+	r.cancelCountdown(model.ReasonHostCancelled)
+}
+
+func (r *GameRoom) handleExtendCountdown(c *GameClient, duration time.Duration) {
+	if !r.requireHost(c) {
+		return
+	}
+	if !r.State.CountdownStarted || r.State.Started {
+		r.pool.SendError(c, model.ErrorNoCountdown, "No countdown is running")
+		return
+	}
+
+	remaining := max(time.Until(r.State.CountdownEndTime), 0)
+	r.pool.gameTimerManager.StartCountdown(r, min(remaining+duration, MaxCountdownDuration))
+}
+
+func (r *GameRoom) handleChooseTeam(c *GameClient, team int) {
+	if r.State.Settings.Mode != model.ModeTeams {
+		r.pool.SendError(c, model.ErrorNotTeamMode, "This room is not playing in teams")
+		return
+	}
+	if r.State.Started {
+		r.pool.SendError(c, model.ErrorGameInProgress, "Teams cannot change during a game")
+		return
+	}
+	if _, seated := r.State.Lives[c.UserId]; !seated || r.connected[c.UserId] != c {
+		r.pool.SendError(c, model.ErrorNotJoined, "Join the room as a player before choosing a team")
+		return
+	}
+	if team < 0 || team >= r.State.Settings.TeamCount {
+		r.pool.SendError(c, model.ErrorInvalidTeam, "Invalid team")
+		return
+	}
+
+	r.State.Teams[c.UserId] = team
+
+	message := protocol.NewMessage(protocol.TeamChangedEvent{
+		RoomID: r.State.RoomID,
+		UserID: c.UserId,
+		Team:   team,
+	})
+
+	r.pool.BroadcastToRoom(r.State.RoomID, message)
+}
+
+func (r *GameRoom) handleAddBot(c *GameClient, difficulty string) {
+	if !r.requireHost(c) {
+		return
+	}
+	if r.State.Started {
+		r.pool.SendError(c, model.ErrorGameInProgress, "Bots cannot join during a game")
+		return
+	}
+	preset, ok := r.pool.botDifficulties[difficulty]
+	if !ok {
+		r.pool.SendError(c, model.ErrorInvalidDifficulty, "Invalid bot difficulty")
+		return
+	}
+	if r.seatsTaken() >= r.Capacity() {
+		r.pool.SendError(c, model.ErrorRoomFull, "Room is full")
+		return
+	}
+
+	r.botCount++
+	words := r.pool.Dictionary(r.State.Language).Words()
+	bot := NewBot(r.pool, r.State.RoomID, botName(difficulty, r.botCount), preset, words, r.State.Settings.MinWordLength)
+	r.bots[bot.Client.UserId] = bot
+
+	go bot.Run()
+	go r.pool.JoinRoom(bot.Client, r.State.RoomID)
+}
+
+func (r *GameRoom) handleRemoveBot(c *GameClient, botID string) {
+	if !r.requireHost(c) {
+		return
+	}
+	bot, exists := r.bots[botID]
+	if !exists {
+		r.pool.SendError(c, model.ErrorBotNotFound, "Bot not found")
+		return
+	}
+
+	delete(r.bots, botID)
+	bot.Stop()
+	go r.pool.LeaveRoom(bot.Client)
+}
+
+func (r *GameRoom) handleRematch(c *GameClient) {
+	if r.spectators[c.UserId] == c {
+		r.pool.SendError(c, model.ErrorSpectator, "Spectators cannot vote for a rematch")
+		return
+	}
+	if r.connected[c.UserId] != c {
+		r.pool.SendError(c, model.ErrorNotJoined, "Join the room before requesting a rematch")
+		return
+	}
+	if !r.State.Finished {
+		r.pool.SendError(c, model.ErrorGameNotFinished, "There is no finished game to rematch")
+		return
+	}
+
+	if c.UserId == r.host() {
+		r.startRematch(c)
+		return
+	}
+
+	r.State.RematchVotes[c.UserId] = true
+	votes, needed := r.rematchVotes()
+
+	message := protocol.NewMessage(protocol.RematchVoteEvent{
+		RoomID:      r.State.RoomID,
+		UserID:      c.UserId,
+		Votes:       votes,
+		VotesNeeded: needed,
+	})
+
+	r.pool.BroadcastToRoom(r.State.RoomID, message)
+
+	if votes >= needed {
+		r.startRematch(c)
+	}
+}
+
+func (r *GameRoom) startRematch(c *GameClient) {
+	r.dropDepartedPlayers()
+	if r.readyPlayers() < r.minPlayersToStart() {
+		r.pool.SendError(c, model.ErrorNotEnoughPlayers, "Not enough players for a rematch")
+		return
+	}
+
+	r.refreshSettings()
+	r.State.Finished = false
+	r.State.WinnerID = ""
+	r.State.RematchVotes = make(map[string]bool)
+	if r.State.Settings.RotateStart {
+		r.State.StartingTurn++
+	}
+
+	message := protocol.NewMessage(protocol.RematchStartedEvent{
+		RoomID:      r.State.RoomID,
+		UserID:      c.UserId,
+		SeriesGames: r.State.SeriesGames,
+		SeriesWins:  maps.Clone(r.State.SeriesWins),
+	})
+
+	r.pool.BroadcastToRoom(r.State.RoomID, message)
+	r.pool.gameTimerManager.StartCountdown(r, DefaultCountdownDuration)
+}
+
+func (r *GameRoom) dropDepartedPlayers() {
+	for _, uid := range slices.Clone(r.State.Players) {
+		if _, away := r.State.Disconnected[uid]; !away && r.connected[uid] == nil {
+			r.engine.RemovePlayer(uid)
+		}
+	}
+}
+
+func (r *GameRoom) rematchVotes() (int, int) {
+	votes, voters := 0, 0
+	for uid, client := range r.connected {
+		if client.IsBot {
+			continue
+		}
+		voters++
+		if r.State.RematchVotes[uid] {
+			votes++
+		}
+	}
+	return votes, voters/2 + 1
+}
+
+func (r *GameRoom) handleCountdownEnd(countdownID int) {
+	if !r.State.CountdownStarted || r.State.CountdownID != countdownID {
+		return
+	}
+
+	if r.readyPlayers() < r.minPlayersToStart() {
+		r.cancelCountdown(model.ReasonNotEnoughPlayers)
+		return
+	}
+
+	r.engine.StartGame()
+}
+
+func (r *GameRoom) refreshSettings() {
+	settings, ok := r.pool.roomSettings(r.State.RoomID)
+	if !ok {
+		return
+	}
+
+	modeChanged := r.State.Settings.Mode != settings.Mode
+	r.State.Settings = settings
+	r.capacity.Store(int32(settings.MaxPlayers))
+	if modeChanged {
+		r.engine = NewGameEngine(r.pool, r)
+	}
+}
+
+func (r *GameRoom) cancelCountdown(reason string) {
+	r.pool.gameTimerManager.StopCountdown(r)
+
+	message := protocol.NewMessage(protocol.CountdownCancelledEvent{
+		RoomID: r.State.RoomID,
+		Reason: reason,
+	})
+
+	r.pool.BroadcastToRoom(r.State.RoomID, message)
+}
+
+func (r *GameRoom) requireHost(c *GameClient) bool {
+	if c.UserId != r.host() {
+		r.pool.SendError(c, model.ErrorNotHost, "Only the host can do that")
+		return false
+	}
+	return true
+}
+
+func (r *GameRoom) host() string {
+	if r.connected[r.State.CreatedBy] != nil {
+		return r.State.CreatedBy
+	}
+	for _, uid := range r.State.Players {
+		if client := r.connected[uid]; client != nil && !client.IsBot {
+			return uid
+		}
+	}
+	return ""
+}
+
+func (r *GameRoom) seatsTaken() int {
+	count := len(r.connected) + len(r.State.Disconnected)
+	for botID := range r.bots {
+		if r.connected[botID] == nil {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *GameRoom) minPlayersToStart() int {
+	return max(r.State.Settings.MinPlayersToStart, model.MinPlayers)
+}
+
+func (r *GameRoom) readyPlayers() int {
+	count := 0
+	for _, uid := range r.State.Players {
+		if r.connected[uid] != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *GameRoom) releaseSeat(userID string, userName string) {
+	r.clearDisconnect(userID)
+	delete(r.State.ResumeTokens, userID)
+	delete(r.State.RematchVotes, userID)
+
+	message := protocol.NewMessage(protocol.UserLeftEvent{
+		RoomID:   r.State.RoomID,
+		UserID:   userID,
+		UserName: userName,
+		Role:     model.RolePlayer,
+	})
+
+	r.pool.BroadcastToRoom(r.State.RoomID, message)
+
+	if _, seated := r.State.Lives[userID]; seated {
+		r.engine.RemovePlayer(userID)
+	}
+}
+
+func (r *GameRoom) clearDisconnect(userID string) {
+	if timer, exists := r.State.DisconnectTimers[userID]; exists {
+		timer.Stop()
+		delete(r.State.DisconnectTimers, userID)
+	}
+	delete(r.State.Disconnected, userID)
+}
+
+func (r *GameRoom) issueResumeToken(c *GameClient) {
+	if _, seated := r.State.Lives[c.UserId]; !seated {
+		return
+	}
+
+	token := uuid.NewString()
+	r.State.ResumeTokens[c.UserId] = token
+
+	message := protocol.NewMessage(protocol.ResumeTokenEvent{
+		RoomID:      r.State.RoomID,
+		UserID:      c.UserId,
+		ResumeToken: token,
+		GracePeriod: int(r.gracePeriod.Seconds()),
+	})
+
+	go c.WriteJSON(message)
+}
+
+func (r *GameRoom) sendResync(c *GameClient) {
+	if r.connected[c.UserId] != c && r.spectators[c.UserId] != c {
+		r.pool.SendError(c, model.ErrorNotJoined, "Join the room before requesting a resync")
+		return
+	}
+
+	turnUserID := ""
+	remainingTime := 0
+	if r.State.Started && len(r.State.Players) > 0 {
+		turnUserID = r.State.Players[r.State.TurnIndex]
+		remainingTime = max(int(time.Until(r.State.TurnEndsAt).Seconds()), 0)
+	}
+
+	event := protocol.ResyncEvent{
+		RoomID:        r.State.RoomID,
+		UserID:        c.UserId,
+		Started:       r.State.Started,
+		HostID:        r.host(),
+		Duration:      r.pool.gameTimerManager.GetRemainingCountdownTime(r),
+		Players:       slices.Clone(r.State.Players),
+		CharSet:       r.State.CharSet,
+		Round:         r.State.Round,
+		TimeLimit:     r.State.TimeLimit,
+		TurnUserID:    turnUserID,
+		RemainingTime: remainingTime,
+		PlayerLives:   maps.Clone(r.State.Lives),
+		PlayerScores:  maps.Clone(r.State.Points),
+		UsedWords:     slices.Clone(r.State.UsedWords),
+		SeriesGames:   r.State.SeriesGames,
+		SeriesWins:    maps.Clone(r.State.SeriesWins),
+	}
+
+	if r.State.Settings.Mode == model.ModeTeams {
+		event.Teams = maps.Clone(r.State.Teams)
+	}
+
+	go c.WriteJSON(protocol.NewMessage(event))
+}
+
+func (r *GameRoom) notifyIfIdle() {
+	if len(r.spectators) > 0 || len(r.State.Disconnected) > 0 {
+		return
+	}
+	for _, client := range r.connected {
+		if !client.IsBot {
+			return
+		}
+	}
+
+	go func() {
+		r.pool.roomIdle <- r
+	}()
+}
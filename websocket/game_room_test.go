@@ -0,0 +1,328 @@
+package websocket
+
+import (
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lakshya1goel/Playzio/bootstrap/dictionary"
+	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"gorm.io/gorm"
+)
+
+func joinWithResumeToken(t *testing.T, server *httptest.Server, roomID uint, userID string, resumeToken string) *testClient {
+	t.Helper()
+
+	client := dialTestClient(t, server, userID)
+	payload := map[string]any{"room_id": roomID}
+	if resumeToken != "" {
+		payload["resume_token"] = resumeToken
+	}
+	client.send(t, model.Join, payload)
+	return client
+}
+
+func TestReconnectWithResumeTokenRestoresSeat(t *testing.T) {
+	pool, server := newTestPool(t)
+
+	first := joinWithResumeToken(t, server, 1, "user:1", "")
+	token := payloadString(first.expect(t, model.ResumeToken, nil), "resume_token")
+	second := joinWithResumeToken(t, server, 1, "guest:a", "")
+	second.expect(t, model.ResumeToken, nil)
+
+	startTestGame(t, []*testClient{first, second})
+
+	first.conn.Close()
+	second.expect(t, model.UserDisconnected, fromUser("user:1"))
+
+	intruder := joinWithResumeToken(t, server, 1, "user:1", "not-the-token")
+	errMsg := intruder.expect(t, model.Error, nil)
+	if errMsg.Payload["code"] != model.ErrorInvalidResumeToken {
+		t.Fatalf("expected %q, got %v", model.ErrorInvalidResumeToken, errMsg.Payload["code"])
+	}
+	if count := pool.RoomCount(1); count != 1 {
+		t.Fatalf("expected the rejected client to stay out of the room, got %d members", count)
+	}
+
+	resumed := joinWithResumeToken(t, server, 1, "user:1", token)
+	received := resumed.expectAll(t, model.Resync, model.ResumeToken)
+	resync := received[model.Resync]
+	if resync.Payload["started"] != true {
+		t.Fatalf("expected resync of a started game, got %v", resync.Payload)
+	}
+	lives, _ := resync.Payload["player_lives"].(map[string]any)
+	if lives["user:1"] != float64(model.DefaultLives) {
+		t.Fatalf("expected seat to keep %d lives, got %v", model.DefaultLives, lives["user:1"])
+	}
+	second.expect(t, model.UserReconnected, fromUser("user:1"))
+
+	rotated := payloadString(received[model.ResumeToken], "resume_token")
+	if rotated == "" || rotated == token {
+		t.Fatalf("expected a fresh resume token, got %q", rotated)
+	}
+}
+
+func TestDisconnectedSeatCountsTowardsCapacity(t *testing.T) {
+	settings := model.DefaultGameSettings()
+	settings.MaxPlayers = 2
+	pool, server := newTestPool(t, model.Room{Model: gorm.Model{ID: 9}, Settings: settings})
+
+	_, clients := joinTestRoom(t, pool, server, 9, "user:1", "guest:a")
+	startTestGame(t, clients)
+
+	clients[0].conn.Close()
+	clients[1].expect(t, model.UserDisconnected, fromUser("user:1"))
+
+	latecomer := joinWithResumeToken(t, server, 9, "guest:b", "")
+	if code := latecomer.expect(t, model.Error, nil).Payload["code"]; code != model.ErrorRoomFull {
+		t.Fatalf("expected %q, got %v", model.ErrorRoomFull, code)
+	}
+	if count := pool.RoomCount(9); count != 1 {
+		t.Fatalf("expected only the connected player in the room, got %d members", count)
+	}
+}
+
+func TestDisconnectedPlayerLosesSeatAfterGracePeriod(t *testing.T) {
+	pool, server := newTestPool(t)
+	pool.gracePeriod = 100 * time.Millisecond
+
+	_, clients := joinTestRoom(t, pool, server, 2, "user:1", "guest:a")
+	startTestGame(t, clients)
+
+	clients[0].conn.Close()
+	clients[1].expect(t, model.UserDisconnected, fromUser("user:1"))
+
+	gameOver := clients[1].expect(t, model.GameOver, nil)
+	if winner := payloadString(gameOver, "winner_id"); winner != "guest:a" {
+		t.Fatalf("expected guest:a to win, got %q", winner)
+	}
+}
+
+func TestSpectatorWatchesWithoutTakingASeat(t *testing.T) {
+	pool, server := newTestPool(t)
+	_, clients := joinTestRoom(t, pool, server, 3, "user:1", "guest:a")
+
+	spectator := dialTestClient(t, server, "guest:watcher")
+	spectator.send(t, model.Join, map[string]any{"room_id": 3, "role": model.RoleSpectator})
+	spectator.expect(t, model.Resync, nil)
+
+	if players, spectators := pool.RoleCounts(3); players != 2 || spectators != 1 {
+		t.Fatalf("expected 2 players and 1 spectator, got %d and %d", players, spectators)
+	}
+
+	clients[0].send(t, model.StartGame, map[string]any{"duration": 0})
+	turn := spectator.expect(t, model.NextTurn, nil)
+	if payloadString(turn, "user_id") == spectator.userID {
+		t.Fatal("spectator was given a turn")
+	}
+
+	spectator.send(t, model.Answer, map[string]any{"answer": validWord(t, pool, payloadString(turn, "char_set"))})
+	errMsg := spectator.expect(t, model.Error, nil)
+	if errMsg.Payload["code"] != model.ErrorSpectator {
+		t.Fatalf("expected %q, got %v", model.ErrorSpectator, errMsg.Payload["code"])
+	}
+	clients[0].expectNone(t, model.Answer, 200*time.Millisecond)
+}
+
+func TestOnlyHostControlsTheCountdown(t *testing.T) {
+	_, server := newTestPool(t)
+
+	host := dialTestClient(t, server, "user:1")
+	host.send(t, model.Join, map[string]any{"room_id": 4})
+	host.expect(t, model.UserJoined, fromUser("user:1"))
+
+	host.send(t, model.StartGame, nil)
+	if code := host.expect(t, model.Error, nil).Payload["code"]; code != model.ErrorNotEnoughPlayers {
+		t.Fatalf("expected %q, got %v", model.ErrorNotEnoughPlayers, code)
+	}
+
+	guest := dialTestClient(t, server, "guest:a")
+	guest.send(t, model.Join, map[string]any{"room_id": 4})
+	joined := guest.expect(t, model.UserJoined, fromUser("guest:a"))
+	if hostID := payloadString(joined, "host_id"); hostID != "user:1" {
+		t.Fatalf("expected user:1 to host, got %q", hostID)
+	}
+
+	guest.send(t, model.StartGame, nil)
+	if code := guest.expect(t, model.Error, nil).Payload["code"]; code != model.ErrorNotHost {
+		t.Fatalf("expected %q, got %v", model.ErrorNotHost, code)
+	}
+
+	host.send(t, model.StartGame, map[string]any{"duration": 60})
+	guest.expect(t, model.TimerStarted, nil)
+
+	host.send(t, model.CancelCountdown, nil)
+	cancelled := guest.expect(t, model.CountdownCancelled, nil)
+	if reason := payloadString(cancelled, "reason"); reason != model.ReasonHostCancelled {
+		t.Fatalf("expected %q, got %q", model.ReasonHostCancelled, reason)
+	}
+	guest.expectNone(t, model.StartGame, 200*time.Millisecond)
+}
+
+func TestRoomWaitsForItsMinimumPlayersToStart(t *testing.T) {
+	settings := model.DefaultGameSettings()
+	settings.MinPlayersToStart = 3
+	pool, server := newTestPool(t, model.Room{Model: gorm.Model{ID: 11}, Settings: settings})
+	_, clients := joinTestRoom(t, pool, server, 11, "user:1", "guest:a")
+	host := clients[0]
+
+	host.send(t, model.StartGame, map[string]any{"duration": 0})
+	if code := host.expect(t, model.Error, nil).Payload["code"]; code != model.ErrorNotEnoughPlayers {
+		t.Fatalf("expected %q, got %v", model.ErrorNotEnoughPlayers, code)
+	}
+
+	third := dialTestClient(t, server, "guest:b")
+	third.send(t, model.Join, map[string]any{"room_id": 11})
+	clients[1].expect(t, model.UserJoined, fromUser("guest:b"))
+
+	host.send(t, model.StartGame, map[string]any{"duration": 0})
+	third.expect(t, model.NextTurn, nil)
+}
+
+func TestRematchByMajorityVoteKeepsRosterAndRotatesStart(t *testing.T) {
+	settings := model.DefaultGameSettings()
+	settings.Lives = 1
+	settings.LifeCap = 1
+	settings.MaxTurnTime = 1
+	settings.MinTurnTime = 1
+	settings.RotateStart = true
+	pool, server := newTestPool(t, model.Room{Model: gorm.Model{ID: 6}, Settings: settings})
+	_, clients := joinTestRoom(t, pool, server, 6, "user:1", "guest:a", "guest:b")
+
+	first, _ := startTestGame(t, clients)
+	if first.userID != "guest:a" {
+		t.Fatalf("expected guest:a to open the first game, got %s", first.userID)
+	}
+	winner := payloadString(clients[0].expect(t, model.GameOver, nil), "winner_id")
+
+	clients[1].send(t, model.Rematch, nil)
+	vote := clients[0].expect(t, model.RematchVote, fromUser("guest:a"))
+	if payloadUint(vote, "votes") != 1 || payloadUint(vote, "votes_needed") != 2 {
+		t.Fatalf("expected 1 of 2 votes, got %v", vote.Payload)
+	}
+
+	clients[2].send(t, model.Rematch, nil)
+	rematch := clients[0].expectAll(t, model.RematchStarted, model.TimerStarted)[model.RematchStarted]
+	if payloadUint(rematch, "series_games") != 1 {
+		t.Fatalf("expected 1 game in the series, got %v", rematch.Payload["series_games"])
+	}
+	wins, _ := rematch.Payload["series_wins"].(map[string]any)
+	if wins[winner] != float64(1) {
+		t.Fatalf("expected %s to lead the series, got %v", winner, wins)
+	}
+
+	second, _ := startTestGame(t, clients)
+	if second.userID != "guest:b" {
+		t.Fatalf("expected the start to rotate to guest:b, got %s", second.userID)
+	}
+	clients[0].send(t, model.Resync, nil)
+	lives, _ := clients[0].expect(t, model.Resync, nil).Payload["player_lives"].(map[string]any)
+	for _, client := range clients {
+		if lives[client.userID] != float64(1) {
+			t.Fatalf("expected lives to reset to 1, got %v", lives)
+		}
+	}
+}
+
+func TestPlayerWhoLeftMidGameSitsOutTheRematch(t *testing.T) {
+	settings := model.DefaultGameSettings()
+	settings.Lives = 1
+	settings.LifeCap = 1
+	settings.MaxTurnTime = 1
+	settings.MinTurnTime = 1
+	pool, server := newTestPool(t, model.Room{Model: gorm.Model{ID: 17}, Settings: settings})
+	_, clients := joinTestRoom(t, pool, server, 17, "user:1", "guest:a", "guest:b")
+
+	startTestGame(t, clients)
+	clients[2].send(t, model.Leave, nil)
+	clients[0].expect(t, model.UserLeft, fromUser("guest:b"))
+	clients[0].expect(t, model.GameOver, nil)
+
+	clients[0].send(t, model.Rematch, nil)
+	clients[0].expect(t, model.RematchStarted, nil)
+	startTestGame(t, clients[:2])
+
+	clients[0].send(t, model.Resync, nil)
+	resync := clients[0].expect(t, model.Resync, nil)
+	lives, _ := resync.Payload["player_lives"].(map[string]any)
+	players, _ := resync.Payload["players"].([]any)
+	if len(lives) != 2 || len(players) != 2 || lives["guest:b"] != nil {
+		t.Fatalf("expected guest:b to sit out the rematch, got players %v with lives %v", players, lives)
+	}
+}
+
+func TestRoomHandlesConcurrentAnswersAndResyncs(t *testing.T) {
+	modes := []string{model.ModeFreeForAll, model.ModeTeams, model.ModeRace}
+	for i, mode := range modes {
+		t.Run(mode, func(t *testing.T) {
+			settings := model.DefaultGameSettings()
+			settings.Mode = mode
+			settings.Lives = 1
+			settings.LifeCap = 1
+			settings.MaxTurnTime = 1
+			settings.MinTurnTime = 1
+			roomID := uint(20 + i)
+			pool, server := newTestPool(t, model.Room{Model: gorm.Model{ID: roomID}, Settings: settings})
+			_, clients := joinTestRoom(t, pool, server, roomID, "user:1", "user:2", "guest:a", "guest:b", "guest:c")
+
+			words := pool.Dictionary(dictionary.DefaultLanguage).Words()
+			clients[0].send(t, model.StartGame, map[string]any{"duration": 0})
+
+			var wg sync.WaitGroup
+			errs := make(chan string, len(clients))
+			for n, client := range clients {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := playUntilGameOver(client, words, n); err != "" {
+						errs <- err
+					}
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func playUntilGameOver(client *testClient, words []string, offset int) string {
+	deadline := time.After(15 * time.Second)
+	for {
+		select {
+		case msg, ok := <-client.messages:
+			if !ok {
+				return client.userID + ": connection closed before game over"
+			}
+			switch msg.Type {
+			case model.GameOver:
+				return ""
+			case model.NextTurn:
+				turnUser := payloadString(msg, "user_id")
+				if payloadUint(msg, "round") <= 2 && (turnUser == "" || turnUser == client.userID) {
+					word := wordFor(words, payloadString(msg, "char_set"), offset)
+					client.conn.WriteJSON(model.GameMessage{Type: model.Answer, Payload: map[string]any{"answer": word}})
+				}
+				client.conn.WriteJSON(model.GameMessage{Type: model.Resync})
+			case model.TurnEnded, model.RoundEnded:
+				client.conn.WriteJSON(model.GameMessage{Type: model.Resync})
+			}
+		case <-deadline:
+			return client.userID + ": timed out waiting for game over"
+		}
+	}
+}
+
+func wordFor(words []string, charSet string, offset int) string {
+	for i := range words {
+		word := words[(i+offset*97)%len(words)]
+		if util.ContainsSubstring(word, charSet) {
+			return word
+		}
+	}
+	return charSet
+}
@@ -0,0 +1,59 @@
+package websocket
+
+import (
+	"math"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+type ScoredAnswer struct {
+	Word            string
+	PromptSolutions int
+	TimeLimit       time.Duration
+	Remaining       time.Duration
+	Rarity          float64
+}
+
+type Scorer interface {
+	Score(answer ScoredAnswer) model.ScoreBreakdown
+}
+
+type flatScorer struct{}
+
+type weightedScorer struct{}
+
+func NewScorer(rule string) Scorer {
+	if rule == model.ScoringFlat {
+		return &flatScorer{}
+	}
+	return &weightedScorer{}
+}
+
+func (s *flatScorer) Score(answer ScoredAnswer) model.ScoreBreakdown {
+	return model.ScoreBreakdown{
+		Base:  FlatScore,
+		Total: FlatScore,
+	}
+}
+
+func (s *weightedScorer) Score(answer ScoredAnswer) model.ScoreBreakdown {
+	breakdown := model.ScoreBreakdown{
+		Base:   ScoreBase,
+		Length: ScoreLengthBonus * max(utf8.RuneCountInString(answer.Word)-ScoreFreeLetters, 0),
+		Rarity: int(math.Round(ScoreMaxRarity * answer.Rarity)),
+	}
+
+	if answer.PromptSolutions > 0 {
+		breakdown.Difficulty = min(ScoreMaxDifficulty*util.MinPromptSolutions/answer.PromptSolutions, ScoreMaxDifficulty)
+	}
+	if answer.TimeLimit > 0 {
+		remaining := min(max(answer.Remaining, 0), answer.TimeLimit)
+		breakdown.Speed = int(math.Round(ScoreMaxSpeed * remaining.Seconds() / answer.TimeLimit.Seconds()))
+	}
+
+	breakdown.Total = breakdown.Base + breakdown.Length + breakdown.Difficulty + breakdown.Speed + breakdown.Rarity
+	return breakdown
+}
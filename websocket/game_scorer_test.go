@@ -0,0 +1,50 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain/model"
+)
+
+func TestFlatScorerAwardsOnePoint(t *testing.T) {
+	breakdown := NewScorer(model.ScoringFlat).Score(ScoredAnswer{Word: "extraordinary"})
+	if breakdown.Total != FlatScore {
+		t.Fatalf("expected %d point, got %+v", FlatScore, breakdown)
+	}
+}
+
+func TestWeightedScorerRewardsHarderAnswers(t *testing.T) {
+	scorer := NewScorer(model.ScoringWeighted)
+
+	easy := scorer.Score(ScoredAnswer{
+		Word:            "cat",
+		PromptSolutions: util.MinPromptSolutions * ScoreMaxDifficulty,
+		TimeLimit:       10 * time.Second,
+		Remaining:       0,
+		Rarity:          0,
+	})
+	hard := scorer.Score(ScoredAnswer{
+		Word:            "quizzical",
+		PromptSolutions: util.MinPromptSolutions,
+		TimeLimit:       10 * time.Second,
+		Remaining:       10 * time.Second,
+		Rarity:          0.5,
+	})
+
+	if easy.Total != ScoreBase+1 {
+		t.Fatalf("expected only the base and minimum difficulty for an easy answer, got %+v", easy)
+	}
+	expected := model.ScoreBreakdown{
+		Base:       ScoreBase,
+		Length:     ScoreLengthBonus * (len("quizzical") - ScoreFreeLetters),
+		Difficulty: ScoreMaxDifficulty,
+		Speed:      ScoreMaxSpeed,
+		Rarity:     ScoreMaxRarity / 2,
+	}
+	expected.Total = expected.Base + expected.Length + expected.Difficulty + expected.Speed + expected.Rarity
+	if hard != expected {
+		t.Fatalf("expected %+v, got %+v", expected, hard)
+	}
+}
@@ -8,72 +8,111 @@ import (
 )
 
 type GameStateManager interface {
-	CreateRoomState(roomID, userId uint) *model.GameRoomState
-	GetRoomState(roomID uint) *model.GameRoomState
+	CreateRoom(roomID uint, userId string, language string, settings model.GameSettings) *GameRoom
+	GetRoom(roomID uint) *GameRoom
 	RemoveRoom(roomID uint)
-	AddPlayer(roomID, userID uint) bool
+	RoomIDs() []uint
+	AddPlayer(roomID uint, userID string) bool
 }
 
 type gameStateManager struct {
-	roomState map[uint]*model.GameRoomState
-	mu        sync.RWMutex
+	pool  *GamePool
+	rooms map[uint]*GameRoom
+	mu    sync.RWMutex
 }
 
-func NewGameStateManager() GameStateManager {
+func NewGameStateManager(pool *GamePool) GameStateManager {
 	return &gameStateManager{
-		roomState: make(map[uint]*model.GameRoomState),
+		pool:  pool,
+		rooms: make(map[uint]*GameRoom),
 	}
 }
 
-func (g *gameStateManager) CreateRoomState(roomID, userId uint) *model.GameRoomState {
+func (g *gameStateManager) CreateRoom(roomID uint, userId string, language string, settings model.GameSettings) *GameRoom {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
 	gameRoomState := &model.GameRoomState{
 		RoomID:           roomID,
 		CreatedBy:        userId,
-		Players:          []uint{userId},
-		Lives:            map[uint]int{userId: InitialLives},
-		Points:           map[uint]int{userId: InitialPoints},
+		Language:         language,
+		Settings:         settings,
+		Players:          []string{},
+		PlayerNames:      make(map[string]string),
+		Lives:            make(map[string]int),
+		Points:           make(map[string]int),
+		ScoreBreakdowns:  make(map[string]model.ScoreBreakdown),
+		WordsAnswered:    make(map[string]int),
+		LivesLost:        make(map[string]int),
+		LettersUsed:      make(map[string]map[string]bool),
+		Teams:            make(map[string]int),
+		TeamLives:        make(map[int]int),
+		TeamTurn:         model.NoTeam,
+		TeamCursors:      make(map[int]int),
+		WinningTeam:      model.NoTeam,
+		Turns:            []model.GameTurn{},
 		TurnIndex:        InitialTurnIndex,
+		StartingTurn:     InitialTurnIndex,
 		CharSet:          "",
 		Started:          false,
 		Round:            0,
 		TimeLimit:        0,
-		WinnerID:         0,
-		CountdownStarted: true,
-		CountdownEndTime: time.Now().Add(CountdownDuration),
+		WinnerID:         "",
+		SeriesWins:       make(map[string]int),
+		RematchVotes:     make(map[string]bool),
+		UsedWords:        []string{},
+		UsedWordSet:      make(map[string]bool),
+		CountdownStarted: false,
+		ResumeTokens:     make(map[string]string),
+		Disconnected:     make(map[string]time.Time),
+		DisconnectTimers: make(map[string]*time.Timer),
 	}
 
-	g.roomState[roomID] = gameRoomState
-	return gameRoomState
+	room := NewGameRoom(g.pool, gameRoomState)
+	g.rooms[roomID] = room
+	go room.Run()
+	return room
 }
 
-func (g *gameStateManager) GetRoomState(roomID uint) *model.GameRoomState {
+func (g *gameStateManager) GetRoom(roomID uint) *GameRoom {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
-	return g.roomState[roomID]
+	return g.rooms[roomID]
 }
 
 func (g *gameStateManager) RemoveRoom(roomID uint) {
 	g.mu.Lock()
-	defer g.mu.Unlock()
-	delete(g.roomState, roomID)
+	room := g.rooms[roomID]
+	delete(g.rooms, roomID)
+	g.mu.Unlock()
+
+	if room != nil {
+		room.Close()
+	}
 }
 
-func (g *gameStateManager) AddPlayer(roomID, userID uint) bool {
-	g.mu.Lock()
-	defer g.mu.Unlock()
+func (g *gameStateManager) RoomIDs() []uint {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	roomIDs := make([]uint, 0, len(g.rooms))
+	for roomID := range g.rooms {
+		roomIDs = append(roomIDs, roomID)
+	}
+	return roomIDs
+}
 
-	room := g.roomState[roomID]
+func (g *gameStateManager) AddPlayer(roomID uint, userID string) bool {
+	room := g.GetRoom(roomID)
 	if room == nil {
 		return false
 	}
 
-	if _, exists := room.Lives[userID]; !exists {
-		room.Players = append(room.Players, userID)
-		room.Lives[userID] = InitialLives
-		room.Points[userID] = InitialPoints
+	state := room.State
+	if _, exists := state.Lives[userID]; !exists {
+		state.Players = append(state.Players, userID)
+		state.Lives[userID] = state.Settings.Lives
+		state.Points[userID] = InitialPoints
 		return true
 	}
 	return false
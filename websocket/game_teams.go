@@ -0,0 +1,214 @@
+package websocket
+
+import (
+	"slices"
+
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
+)
+
+func (g *gameEngine) isTeamMode() bool {
+	return g.GameRoomState.Settings.Mode == model.ModeTeams
+}
+
+func (g *gameEngine) assignTeams() {
+	state := g.GameRoomState
+	teamCount := state.Settings.TeamCount
+	teams := make(map[string]int, len(state.Players))
+	sizes := make([]int, teamCount)
+
+	for _, uid := range state.Players {
+		if team, exists := state.Teams[uid]; exists && team >= 0 && team < teamCount {
+			teams[uid] = team
+			sizes[team]++
+		}
+	}
+	for _, uid := range state.Players {
+		if _, assigned := teams[uid]; assigned {
+			continue
+		}
+		team := smallestTeam(sizes)
+		teams[uid] = team
+		sizes[team]++
+	}
+
+	for {
+		empty := slices.Index(sizes, 0)
+		largest := largestTeam(sizes)
+		if empty < 0 || sizes[largest] < 2 {
+			break
+		}
+		for i := len(state.Players) - 1; i >= 0; i-- {
+			if teams[state.Players[i]] == largest {
+				teams[state.Players[i]] = empty
+				break
+			}
+		}
+		sizes[largest]--
+		sizes[empty]++
+	}
+
+	state.Teams = teams
+	state.TeamLives = make(map[int]int, teamCount)
+	state.TeamCursors = make(map[int]int, teamCount)
+	state.TeamTurn = model.NoTeam
+	for team := range teamCount {
+		state.TeamCursors[team] = state.StartingTurn
+		if sizes[team] > 0 {
+			state.TeamLives[team] = state.Settings.Lives
+		}
+		g.syncTeamLives(team)
+	}
+}
+
+func smallestTeam(sizes []int) int {
+	smallest := 0
+	for team, size := range sizes {
+		if size < sizes[smallest] {
+			smallest = team
+		}
+	}
+	return smallest
+}
+
+func largestTeam(sizes []int) int {
+	largest := 0
+	for team, size := range sizes {
+		if size > sizes[largest] {
+			largest = team
+		}
+	}
+	return largest
+}
+
+func (g *gameEngine) teamMembers(team int) []string {
+	members := make([]string, 0)
+	for _, uid := range g.GameRoomState.Players {
+		if t, exists := g.GameRoomState.Teams[uid]; exists && t == team {
+			members = append(members, uid)
+		}
+	}
+	return members
+}
+
+func (g *gameEngine) syncTeamLives(team int) {
+	for _, uid := range g.teamMembers(team) {
+		g.GameRoomState.Lives[uid] = g.GameRoomState.TeamLives[team]
+	}
+}
+
+func (g *gameEngine) loseLife(userID string) {
+	g.GameRoomState.LivesLost[userID]++
+	if !g.isTeamMode() {
+		g.GameRoomState.Lives[userID]--
+		return
+	}
+
+	team := g.GameRoomState.Teams[userID]
+	g.GameRoomState.TeamLives[team] = max(g.GameRoomState.TeamLives[team]-1, 0)
+	g.syncTeamLives(team)
+}
+
+func (g *gameEngine) gainLife(userID string) bool {
+	if g.GameRoomState.Lives[userID] >= g.GameRoomState.Settings.LifeCap {
+		return false
+	}
+	if !g.isTeamMode() {
+		g.GameRoomState.Lives[userID]++
+		return true
+	}
+
+	team := g.GameRoomState.Teams[userID]
+	g.GameRoomState.TeamLives[team]++
+	g.syncTeamLives(team)
+	return true
+}
+
+func (g *gameEngine) removeFromTeam(userID string) {
+	team, exists := g.GameRoomState.Teams[userID]
+	if !exists {
+		return
+	}
+
+	delete(g.GameRoomState.Teams, userID)
+	g.GameRoomState.Lives[userID] = 0
+	if len(g.teamMembers(team)) == 0 {
+		g.GameRoomState.TeamLives[team] = 0
+	}
+}
+
+func (g *gameEngine) startNextTeamTurn() {
+	state := g.GameRoomState
+	teamCount := state.Settings.TeamCount
+
+	for i := 1; i <= teamCount; i++ {
+		team := (state.TeamTurn + i + teamCount) % teamCount
+		members := g.teamMembers(team)
+		if state.TeamLives[team] <= 0 || len(members) == 0 {
+			continue
+		}
+
+		if state.TeamTurn != model.NoTeam && team <= state.TeamTurn {
+			state.Round++
+		}
+		state.TeamTurn = team
+		uid := members[state.TeamCursors[team]%len(members)]
+		state.TeamCursors[team]++
+		state.TurnIndex = slices.Index(state.Players, uid)
+		g.startTurn(uid)
+		return
+	}
+}
+
+func (g *gameEngine) teamPoints(team int) int {
+	points := 0
+	for _, uid := range g.teamMembers(team) {
+		points += g.GameRoomState.Points[uid]
+	}
+	return points
+}
+
+func (g *gameEngine) checkTeamEndCondition() bool {
+	aliveTeams := make([]int, 0)
+	bestTeam := model.NoTeam
+	for team := range g.GameRoomState.Settings.TeamCount {
+		if len(g.teamMembers(team)) == 0 {
+			continue
+		}
+		if g.GameRoomState.TeamLives[team] > 0 {
+			aliveTeams = append(aliveTeams, team)
+		}
+		if bestTeam == model.NoTeam || g.teamPoints(team) > g.teamPoints(bestTeam) {
+			bestTeam = team
+		}
+	}
+
+	if len(aliveTeams) > MinAlivePlayersForGameEnd {
+		return false
+	}
+
+	if len(aliveTeams) == 1 {
+		bestTeam = aliveTeams[0]
+	}
+	g.GameRoomState.WinningTeam = bestTeam
+	g.endGame("")
+	return true
+}
+
+func (g *gameEngine) teamResults() []protocol.TeamResult {
+	results := make([]protocol.TeamResult, 0, g.GameRoomState.Settings.TeamCount)
+	for team := range g.GameRoomState.Settings.TeamCount {
+		members := g.teamMembers(team)
+		if len(members) == 0 {
+			continue
+		}
+		results = append(results, protocol.TeamResult{
+			Team:    team,
+			Players: members,
+			Points:  g.teamPoints(team),
+			Lives:   g.GameRoomState.TeamLives[team],
+			Winner:  team == g.GameRoomState.WinningTeam,
+		})
+	}
+	return results
+}
@@ -0,0 +1,49 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"gorm.io/gorm"
+)
+
+func TestTeamsAlternateTurnsAndShareLives(t *testing.T) {
+	settings := model.DefaultGameSettings()
+	settings.Mode = model.ModeTeams
+	settings.Lives = 2
+	settings.LifeCap = 2
+	settings.MaxTurnTime = 1
+	settings.MinTurnTime = 1
+	pool, server := newTestPool(t, model.Room{Model: gorm.Model{ID: 5}, Settings: settings})
+	_, clients := joinTestRoom(t, pool, server, 5, "user:1", "guest:a", "guest:b")
+
+	for i, team := range []int{0, 0, 1} {
+		clients[i].send(t, model.JoinTeam, map[string]any{"team": team})
+		clients[0].expect(t, model.TeamChanged, fromUser(clients[i].userID))
+	}
+
+	clients[0].send(t, model.StartGame, map[string]any{"duration": 0})
+
+	for _, expected := range []struct {
+		userID string
+		team   uint
+	}{{"user:1", 0}, {"guest:b", 1}, {"guest:a", 0}} {
+		turn := clients[1].expect(t, model.NextTurn, nil)
+		if payloadString(turn, "user_id") != expected.userID || payloadUint(turn, "team") != expected.team {
+			t.Fatalf("expected turn for %s on team %d, got %v", expected.userID, expected.team, turn.Payload)
+		}
+	}
+
+	gameOver := clients[2].expect(t, model.GameOver, nil)
+	if payloadUint(gameOver, "winning_team") != 1 {
+		t.Fatalf("expected team 1 to win, got %v", gameOver.Payload["winning_team"])
+	}
+	results, _ := gameOver.Payload["team_results"].([]any)
+	if len(results) != 2 {
+		t.Fatalf("expected results for 2 teams, got %v", gameOver.Payload["team_results"])
+	}
+	losers, _ := results[0].(map[string]any)
+	if losers["lives"] != float64(0) || len(losers["players"].([]any)) != 2 || losers["winner"] != false {
+		t.Fatalf("expected team 0 to lose both pooled lives, got %v", losers)
+	}
+}
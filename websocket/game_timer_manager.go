@@ -3,9 +3,9 @@ package websocket
 import "time"
 
 type GameTimerManager interface {
-	StartCountdown(roomID uint, duration time.Duration)
-	GetRemainingCountdownTime(roomID uint) int
-	StopCountdown(roomID uint)
+	StartCountdown(room *GameRoom, duration time.Duration)
+	GetRemainingCountdownTime(room *GameRoom) int
+	StopCountdown(room *GameRoom)
 }
 
 type gameTimerManager struct {
@@ -18,29 +18,32 @@ func NewGameTimerManager(pool *GamePool) GameTimerManager {
 	}
 }
 
-func (g *gameTimerManager) StartCountdown(roomID uint, duration time.Duration) {
-	roomState := g.pool.gameStateManager.GetRoomState(roomID)
-	if roomState == nil {
-		return
-	}
+func (g *gameTimerManager) StartCountdown(room *GameRoom, duration time.Duration) {
+	g.StopCountdown(room)
 
+	roomState := room.State
+	roomState.CountdownID++
+	countdownID := roomState.CountdownID
+	roomState.CountdownStarted = true
+	roomState.CountdownEndTime = time.Now().Add(duration)
 	roomState.CountdownTimer = time.AfterFunc(duration, func() {
-		g.pool.handleCountdownEnd(roomID)
+		room.post(gameEvent{eventType: countdownEndEvent, countdownID: countdownID})
 	})
 
-	g.pool.BroadcastTimerStarted(roomID, int(duration.Seconds()))
+	g.pool.BroadcastTimerStarted(roomState.RoomID, int(duration.Seconds()))
 }
 
-func (g *gameTimerManager) StopCountdown(roomID uint) {
-	roomState := g.pool.gameStateManager.GetRoomState(roomID)
-	if roomState != nil && roomState.CountdownTimer != nil {
-		roomState.CountdownTimer.Stop()
+func (g *gameTimerManager) StopCountdown(room *GameRoom) {
+	if room.State.CountdownTimer != nil {
+		room.State.CountdownTimer.Stop()
+		room.State.CountdownTimer = nil
 	}
+	room.State.CountdownStarted = false
 }
 
-func (g *gameTimerManager) GetRemainingCountdownTime(roomID uint) int {
-	roomState := g.pool.gameStateManager.GetRoomState(roomID)
-	if roomState == nil || !roomState.CountdownStarted || roomState.Started {
+func (g *gameTimerManager) GetRemainingCountdownTime(room *GameRoom) int {
+	roomState := room.State
+	if !roomState.CountdownStarted || roomState.Started {
 		return 0
 	}
 
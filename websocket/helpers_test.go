@@ -0,0 +1,283 @@
+package websocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gorilla "github.com/gorilla/websocket"
+	"github.com/lakshya1goel/Playzio/bootstrap/dictionary"
+	"github.com/lakshya1goel/Playzio/bootstrap/util"
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
+	"gorm.io/gorm"
+)
+
+const testMessageTimeout = 3 * time.Second
+
+type testMessage struct {
+	Type    string         `json:"type"`
+	Payload map[string]any `json:"payload"`
+	raw     []byte
+}
+
+type testClient struct {
+	userID   string
+	conn     *gorilla.Conn
+	messages chan testMessage
+}
+
+type testRooms map[uint]model.Room
+
+func (r testRooms) GetRoomByID(c *gin.Context, id uint) (model.Room, error) {
+	room, exists := r[id]
+	if !exists {
+		return model.Room{}, gorm.ErrRecordNotFound
+	}
+	return room, nil
+}
+
+func newTestPool(t *testing.T, rooms ...model.Room) (*GamePool, *httptest.Server) {
+	t.Helper()
+	return newTestNode(t, nil, rooms...)
+}
+
+func newTestNode(t *testing.T, bus RoomBus, rooms ...model.Room) (*GamePool, *httptest.Server) {
+	t.Helper()
+
+	dict, err := dictionary.NewEmbeddedDictionary(dictionary.DefaultLanguage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := dictionary.NewRegistry(dictionary.DefaultLanguage)
+	registry.Register(dict)
+
+	var provider RoomProvider
+	if len(rooms) > 0 {
+		stored := make(testRooms)
+		for _, room := range rooms {
+			stored[room.ID] = room
+		}
+		provider = stored
+	}
+
+	pool := NewGamePool(registry, provider, nil, bus)
+	go pool.Start()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := r.URL.Query().Get("participant_id")
+		conn, err := util.Upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		client := &GameClient{
+			BaseClient: BaseClient{
+				Conn:     conn,
+				UserId:   userID,
+				UserName: "player_" + userID,
+			},
+			Pool:            pool,
+			ProtocolVersion: protocol.Version,
+		}
+		go pool.Read(client)
+	}))
+	t.Cleanup(server.Close)
+
+	return pool, server
+}
+
+func dialTestClient(t *testing.T, server *httptest.Server, userID string) *testClient {
+	t.Helper()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "?participant_id=" + neturl.QueryEscape(userID)
+	conn, _, err := gorilla.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &testClient{
+		userID:   userID,
+		conn:     conn,
+		messages: make(chan testMessage, 1024),
+	}
+	go func() {
+		defer close(client.messages)
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			msg := testMessage{raw: data}
+			if err := json.Unmarshal(data, &msg); err != nil {
+				return
+			}
+			client.messages <- msg
+		}
+	}()
+	t.Cleanup(func() { conn.Close() })
+
+	return client
+}
+
+func (c *testClient) send(t *testing.T, msgType string, payload map[string]any) {
+	t.Helper()
+	if err := c.conn.WriteJSON(model.GameMessage{Type: msgType, Payload: payload}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (c *testClient) expect(t *testing.T, msgType string, match func(testMessage) bool) testMessage {
+	t.Helper()
+
+	deadline := time.After(testMessageTimeout)
+	for {
+		select {
+		case msg, ok := <-c.messages:
+			if !ok {
+				t.Fatalf("user %s: connection closed while waiting for %q", c.userID, msgType)
+			}
+			checkProtocol(t, msg)
+			if msg.Type == msgType && (match == nil || match(msg)) {
+				return msg
+			}
+		case <-deadline:
+			t.Fatalf("user %s: timed out waiting for %q", c.userID, msgType)
+		}
+	}
+}
+
+func (c *testClient) expectAll(t *testing.T, msgTypes ...string) map[string]testMessage {
+	t.Helper()
+
+	received := make(map[string]testMessage)
+	deadline := time.After(testMessageTimeout)
+	for len(received) < len(msgTypes) {
+		select {
+		case msg, ok := <-c.messages:
+			if !ok {
+				t.Fatalf("user %s: connection closed while waiting for %v", c.userID, msgTypes)
+			}
+			checkProtocol(t, msg)
+			for _, msgType := range msgTypes {
+				if msg.Type == msgType {
+					received[msgType] = msg
+				}
+			}
+		case <-deadline:
+			t.Fatalf("user %s: timed out waiting for %v", c.userID, msgTypes)
+		}
+	}
+	return received
+}
+
+func (c *testClient) expectNone(t *testing.T, msgType string, within time.Duration) {
+	t.Helper()
+
+	deadline := time.After(within)
+	for {
+		select {
+		case msg, ok := <-c.messages:
+			if !ok {
+				return
+			}
+			checkProtocol(t, msg)
+			if msg.Type == msgType {
+				t.Fatalf("user %s: unexpected %q message: %v", c.userID, msgType, msg.Payload)
+			}
+		case <-deadline:
+			return
+		}
+	}
+}
+
+func checkProtocol(t *testing.T, msg testMessage) {
+	t.Helper()
+	if _, err := protocol.DecodeOutbound(msg.raw); err != nil {
+		t.Fatalf("message does not match the protocol: %v: %s", err, msg.raw)
+	}
+}
+
+func payloadUint(msg testMessage, key string) uint {
+	value, _ := msg.Payload[key].(float64)
+	return uint(value)
+}
+
+func payloadString(msg testMessage, key string) string {
+	value, _ := msg.Payload[key].(string)
+	return value
+}
+
+func fromUser(userID string) func(testMessage) bool {
+	return func(msg testMessage) bool {
+		return payloadString(msg, "user_id") == userID
+	}
+}
+
+func joinTestRoom(t *testing.T, pool *GamePool, server *httptest.Server, roomID uint, userIDs ...string) (*GameRoom, []*testClient) {
+	t.Helper()
+
+	clients := make([]*testClient, 0, len(userIDs))
+	for _, userID := range userIDs {
+		client := dialTestClient(t, server, userID)
+		client.send(t, model.Join, map[string]any{"room_id": roomID})
+		client.expect(t, model.UserJoined, fromUser(userID))
+		clients = append(clients, client)
+	}
+
+	room := pool.gameStateManager.GetRoom(roomID)
+	if room == nil {
+		t.Fatalf("room %d was not created", roomID)
+	}
+	return room, clients
+}
+
+func startTestGame(t *testing.T, clients []*testClient) (*testClient, string) {
+	t.Helper()
+
+	clients[0].send(t, model.StartGame, map[string]any{"duration": 0})
+
+	turn := clients[0].expect(t, model.NextTurn, nil)
+	for _, client := range clients {
+		if client.userID == payloadString(turn, "user_id") {
+			return client, turn.Payload["char_set"].(string)
+		}
+	}
+	t.Fatalf("next turn for unknown user %v", turn.Payload["user_id"])
+	return nil, ""
+}
+
+func otherClient(clients []*testClient, current *testClient) *testClient {
+	for _, client := range clients {
+		if client != current {
+			return client
+		}
+	}
+	return nil
+}
+
+func validWord(t *testing.T, pool *GamePool, charSet string, exclude ...string) string {
+	t.Helper()
+
+	for _, word := range pool.Dictionary(dictionary.DefaultLanguage).Words() {
+		if !util.ContainsSubstring(word, charSet) {
+			continue
+		}
+		excluded := false
+		for _, other := range exclude {
+			if other == word {
+				excluded = true
+			}
+		}
+		if !excluded {
+			return word
+		}
+	}
+	t.Fatalf("no word in dictionary contains %q", charSet)
+	return ""
+}
@@ -0,0 +1,181 @@
+package websocket
+
+import (
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"github.com/lakshya1goel/Playzio/domain/protocol"
+)
+
+type raceEngine struct {
+	*gameEngine
+}
+
+func NewRaceEngine(pool *GamePool, room *GameRoom) GameEngine {
+	return &raceEngine{
+		gameEngine: newGameEngine(pool, room),
+	}
+}
+
+func (r *raceEngine) StartGame() {
+	if r.GameRoomState.Started {
+		return
+	}
+
+	r.resetGame()
+	r.GameRoomState.RoundAnswers = nil
+	r.broadcastStartGame()
+	r.StartNextTurn()
+}
+
+func (r *raceEngine) StartNextTurn() {
+	if !r.GameRoomState.Started {
+		return
+	}
+
+	if r.checkEndCondition() {
+		return
+	}
+
+	if r.GameRoomState.RoundAnswers != nil {
+		r.GameRoomState.Round++
+	}
+	r.GameRoomState.TimeLimit = r.turnTimeLimit()
+	r.GameRoomState.CharSet = r.Dictionary.GeneratePrompt(r.GameRoomState.Round)
+	r.GameRoomState.RoundAnswers = make(map[string]string)
+	r.GameRoomState.TurnID++
+
+	message := protocol.NewMessage(protocol.NextTurnEvent{
+		RoomID:      r.GameRoomState.RoomID,
+		CharSet:     r.GameRoomState.CharSet,
+		TimeLimit:   r.GameRoomState.TimeLimit,
+		Round:       r.GameRoomState.Round,
+		PlayerLives: maps.Clone(r.GameRoomState.Lives),
+	})
+
+	r.Pool.BroadcastToRoom(r.GameRoomState.RoomID, message)
+
+	r.Stop()
+	turnID := r.GameRoomState.TurnID
+	r.GameRoomState.TurnEndsAt = time.Now().Add(time.Duration(r.GameRoomState.TimeLimit) * time.Second)
+	r.GameRoomState.TurnTimer = time.AfterFunc(time.Duration(r.GameRoomState.TimeLimit)*time.Second, func() {
+		r.Room.post(gameEvent{eventType: turnTimeoutEvent, turnID: turnID})
+	})
+}
+
+func (r *raceEngine) HandleAnswer(c *GameClient, answer string) {
+	if !r.GameRoomState.Started {
+		r.Pool.SendError(c, model.ErrorGameNotStarted, "The game has not started")
+		return
+	}
+
+	if r.GameRoomState.Lives[c.UserId] <= 0 {
+		r.Pool.SendError(c, model.ErrorEliminated, "You have been eliminated")
+		return
+	}
+
+	if _, answered := r.GameRoomState.RoundAnswers[c.UserId]; answered {
+		r.Pool.SendError(c, model.ErrorAlreadyAnswered, "You already answered this round")
+		return
+	}
+
+	if reason := r.validateAnswer(answer); reason != "" {
+		r.broadcastAnswer(c.UserId, answer, false, reason, nil)
+		return
+	}
+
+	word := strings.ToLower(answer)
+	rank := len(r.GameRoomState.RoundAnswers) + 1
+	r.GameRoomState.RoundAnswers[c.UserId] = word
+	r.GameRoomState.UsedWordSet[word] = true
+	r.GameRoomState.UsedWords = append(r.GameRoomState.UsedWords, word)
+	r.GameRoomState.WordsAnswered[c.UserId]++
+	r.recordTurn(c.UserId, word, model.ReasonCorrectAnswer)
+
+	var breakdown *model.ScoreBreakdown
+	if rank <= r.GameRoomState.Settings.RaceWinners {
+		score := r.scoreAnswer(word)
+		r.GameRoomState.Points[c.UserId] += score.Total
+		r.GameRoomState.ScoreBreakdowns[c.UserId] = r.GameRoomState.ScoreBreakdowns[c.UserId].Add(score)
+		breakdown = &score
+	}
+
+	r.broadcastAnswer(c.UserId, answer, true, "", breakdown)
+	r.trackLetters(c.UserId, word)
+
+	if r.allAnswered() {
+		r.endRound()
+	}
+}
+
+func (r *raceEngine) HandleTurnTimeout(turnID int) {
+	if !r.GameRoomState.Started || r.GameRoomState.TurnID != turnID {
+		return
+	}
+
+	r.endRound()
+}
+
+func (r *raceEngine) RemovePlayer(userID string) {
+	if !r.GameRoomState.Started {
+		r.gameEngine.RemovePlayer(userID)
+		return
+	}
+
+	if r.GameRoomState.Lives[userID] == 0 {
+		return
+	}
+
+	r.GameRoomState.LivesLost[userID] += r.GameRoomState.Lives[userID]
+	r.GameRoomState.Lives[userID] = 0
+
+	if r.checkEndCondition() {
+		return
+	}
+
+	if r.allAnswered() {
+		r.endRound()
+	}
+}
+
+func (r *raceEngine) allAnswered() bool {
+	for _, uid := range r.GameRoomState.Players {
+		if r.GameRoomState.Lives[uid] <= 0 {
+			continue
+		}
+		if _, answered := r.GameRoomState.RoundAnswers[uid]; !answered {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *raceEngine) endRound() {
+	r.Stop()
+
+	for _, uid := range r.GameRoomState.Players {
+		if r.GameRoomState.Lives[uid] <= 0 {
+			continue
+		}
+		if _, answered := r.GameRoomState.RoundAnswers[uid]; answered {
+			continue
+		}
+		r.loseLife(uid)
+		r.recordTurn(uid, "", model.ReasonTimeout)
+	}
+
+	message := protocol.NewMessage(protocol.RoundEndedEvent{
+		RoomID:       r.GameRoomState.RoomID,
+		Round:        r.GameRoomState.Round,
+		CharSet:      r.GameRoomState.CharSet,
+		Answers:      maps.Clone(r.GameRoomState.RoundAnswers),
+		PlayerLives:  maps.Clone(r.GameRoomState.Lives),
+		PlayerScores: maps.Clone(r.GameRoomState.Points),
+	})
+
+	r.Pool.BroadcastToRoom(r.GameRoomState.RoomID, message)
+
+	r.StartNextTurn()
+}
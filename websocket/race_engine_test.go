@@ -0,0 +1,49 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/lakshya1goel/Playzio/domain/model"
+	"gorm.io/gorm"
+)
+
+func TestRaceModeScoresFirstAnswersAndPunishesSilence(t *testing.T) {
+	settings := model.DefaultGameSettings()
+	settings.Mode = model.ModeRace
+	settings.RaceWinners = 1
+	settings.MaxTurnTime = 1
+	settings.MinTurnTime = 1
+	pool, server := newTestPool(t, model.Room{Model: gorm.Model{ID: 6}, Settings: settings})
+	_, clients := joinTestRoom(t, pool, server, 6, "user:1", "guest:a", "guest:b")
+
+	clients[0].send(t, model.StartGame, map[string]any{"duration": 0})
+	round := clients[0].expect(t, model.NextTurn, nil)
+	if _, hasOwner := round.Payload["user_id"]; hasOwner {
+		t.Fatalf("race rounds should not belong to one player, got %v", round.Payload)
+	}
+	charSet := payloadString(round, "char_set")
+
+	first := validWord(t, pool, charSet)
+	clients[0].send(t, model.Answer, map[string]any{"answer": first})
+	scored := clients[2].expect(t, model.Answer, fromUser("user:1"))
+	if scored.Payload["correct"] != true || scored.Payload["score_breakdown"] == nil {
+		t.Fatalf("expected the first answer to score, got %v", scored.Payload)
+	}
+
+	clients[1].send(t, model.Answer, map[string]any{"answer": validWord(t, pool, charSet, first)})
+	unscored := clients[2].expect(t, model.Answer, fromUser("guest:a"))
+	if unscored.Payload["correct"] != true || unscored.Payload["score_breakdown"] != nil {
+		t.Fatalf("expected the second answer to be accepted without scoring, got %v", unscored.Payload)
+	}
+
+	clients[0].send(t, model.Answer, map[string]any{"answer": first})
+	if code := clients[0].expect(t, model.Error, nil).Payload["code"]; code != model.ErrorAlreadyAnswered {
+		t.Fatalf("expected %q, got %v", model.ErrorAlreadyAnswered, code)
+	}
+
+	ended := clients[2].expect(t, model.RoundEnded, nil)
+	lives, _ := ended.Payload["player_lives"].(map[string]any)
+	if lives["guest:b"] != float64(settings.Lives-1) || lives["user:1"] != float64(settings.Lives) || lives["guest:a"] != float64(settings.Lives) {
+		t.Fatalf("expected only the silent player to lose a life, got %v", lives)
+	}
+}